@@ -34,6 +34,14 @@ func As(kind Kind, err error) error {
 		return tooManyRequestsError{error: err}
 	case TimeoutError:
 		return timeoutError{error: err}
+	case UnimplementedError:
+		return unimplementedError{error: err}
+	case UnavailableError:
+		return unavailableError{error: err}
+	case CanceledError:
+		return canceledError{error: err}
+	case GoneError:
+		return goneError{error: err}
 	default:
 		return unknownError{error: err}
 	}
@@ -63,7 +71,8 @@ func IsFinal(err error) bool {
 	switch kind := KindOf(err); kind {
 	case UnknownError,
 		TooManyRequestsError,
-		TimeoutError:
+		TimeoutError,
+		UnavailableError:
 		return false
 	default:
 		return true
@@ -81,7 +90,9 @@ func IsUser(err error) bool {
 		NotFoundError,
 		ConflictError,
 		PreconditionFailedError,
-		TooManyRequestsError:
+		TooManyRequestsError,
+		CanceledError,
+		GoneError:
 		return true
 	default:
 		return false
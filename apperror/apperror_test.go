@@ -221,6 +221,34 @@ var testCases = []testCase{
 		wrapper:           apperror.AsTimeout,
 		matcher:           apperror.IsTimeout,
 	},
+	{
+		kind:              apperror.UnimplementedError,
+		stringConstructor: apperror.Unimplemented,
+		formatConstructor: apperror.Unimplementedf,
+		wrapper:           apperror.AsUnimplemented,
+		matcher:           apperror.IsUnimplemented,
+	},
+	{
+		kind:              apperror.UnavailableError,
+		stringConstructor: apperror.Unavailable,
+		formatConstructor: apperror.Unavailablef,
+		wrapper:           apperror.AsUnavailable,
+		matcher:           apperror.IsUnavailable,
+	},
+	{
+		kind:              apperror.CanceledError,
+		stringConstructor: apperror.Canceled,
+		formatConstructor: apperror.Canceledf,
+		wrapper:           apperror.AsCanceled,
+		matcher:           apperror.IsCanceled,
+	},
+	{
+		kind:              apperror.GoneError,
+		stringConstructor: apperror.Gone,
+		formatConstructor: apperror.Gonef,
+		wrapper:           apperror.AsGone,
+		matcher:           apperror.IsGone,
+	},
 }
 
 func TestAs_accepts_empty_messages(t *testing.T) {
@@ -461,6 +489,8 @@ func TestIsUser(t *testing.T) {
 		apperror.ConflictError:           {},
 		apperror.PreconditionFailedError: {},
 		apperror.TooManyRequestsError:    {},
+		apperror.CanceledError:           {},
+		apperror.GoneError:               {},
 	}
 
 	for _, kind := range apperror.KindValues() {
@@ -476,6 +506,28 @@ func TestIsUser(t *testing.T) {
 	}
 }
 
+func TestIsFinal(t *testing.T) {
+	transientKinds := map[apperror.Kind]struct{}{
+		apperror.UnknownError:         {},
+		apperror.TooManyRequestsError: {},
+		apperror.TimeoutError:         {},
+		apperror.UnavailableError:     {},
+	}
+
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			_, transient := transientKinds[kind]
+			expected := !transient
+
+			err := apperror.New(kind, message)
+			got := apperror.IsFinal(err)
+			if got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
 func assertErrorKind(
 	t *testing.T,
 	err error,
@@ -0,0 +1,129 @@
+package apperror
+
+import (
+	"log/slog"
+	"slices"
+)
+
+// attrsError attaches key-value attributes to an error without changing its
+// kind or message.
+//
+// It is handled through a pointer because its slice makes the struct
+// incomparable. Comparing two errors with == or using one as a map key
+// panics at runtime if the dynamic type is incomparable, while pointers
+// are always comparable.
+type attrsError struct {
+	error
+	attrs []slog.Attr
+}
+
+func (e *attrsError) Unwrap() error {
+	return e.error
+}
+
+// LogValue implements slog.LogValuer.
+func (e *attrsError) LogValue() slog.Value {
+	return LogValue(e)
+}
+
+// WithAttrs attaches key-value attributes to an error.
+// The kind and the message of the error are preserved.
+// It returns nil for nil errors.
+//
+// The attributes can be retrieved with Attrs, even if the error is wrapped
+// afterwards. They are included when logging the error with slog only while
+// it is the outermost error, since wrapping it with fmt.Errorf hides its
+// slog.LogValuer implementation. Use ReplaceAttr to log them regardless.
+func WithAttrs(err error, attrs ...slog.Attr) error {
+	if err == nil {
+		return nil
+	}
+
+	return &attrsError{
+		error: err,
+		attrs: slices.Clone(attrs),
+	}
+}
+
+// Attrs collects the attributes attached with WithAttrs along the whole wrap
+// chain of an error, from the outermost to the innermost.
+// It returns nil if there are none.
+func Attrs(err error) []slog.Attr {
+	var attrs []slog.Attr
+	walk(err, func(err error) {
+		if e, ok := err.(*attrsError); ok {
+			attrs = append(attrs, e.attrs...)
+		}
+	})
+
+	return attrs
+}
+
+// LogValue returns a structured representation of an error, which contains
+// its kind, its message and its attributes. The kind is logged with the
+// stable name returned by Kind.MarshalText, e.g., "not_found".
+//
+// Errors returned by WithAttrs use it to implement slog.LogValuer, and
+// ReplaceAttr uses it for every error.
+func LogValue(err error) slog.Value {
+	if err == nil {
+		return slog.GroupValue(
+			slog.String(kindLogKey, kindNames[OK]),
+		)
+	}
+
+	values := []slog.Attr{
+		slog.String(kindLogKey, kindNames[KindOf(err)]),
+		slog.String(messageLogKey, err.Error()),
+	}
+	if attrs := Attrs(err); len(attrs) > 0 {
+		values = append(values, slog.Attr{
+			Key:   attrsLogKey,
+			Value: slog.GroupValue(attrs...),
+		})
+	}
+
+	return slog.GroupValue(values...)
+}
+
+// ReplaceAttr logs every error with LogValue, so that the attributes
+// attached with WithAttrs are logged even if the error was wrapped
+// afterwards. It is meant to be used as slog.HandlerOptions.ReplaceAttr:
+//
+//	handler := slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+//		ReplaceAttr: apperror.ReplaceAttr,
+//	})
+//
+// Attributes whose value is not an error are returned as is.
+func ReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if err, ok := a.Value.Any().(error); ok {
+		a.Value = LogValue(err)
+	}
+
+	return a
+}
+
+// walk visits every error in the wrap chain of err, including joined errors,
+// in depth-first order.
+func walk(err error, visit func(error)) {
+	if err == nil {
+		return
+	}
+
+	visit(err)
+
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		walk(x.Unwrap(), visit)
+	case interface{ Unwrap() []error }:
+		for _, child := range x.Unwrap() {
+			walk(child, visit)
+		}
+	}
+}
+
+const (
+	kindLogKey    = "kind"
+	messageLogKey = "message"
+	attrsLogKey   = "attrs"
+)
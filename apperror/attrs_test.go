@@ -0,0 +1,185 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+func ExampleWithAttrs() {
+	err := apperror.NotFound("order not found")
+	err = apperror.WithAttrs(err, slog.Int("order_id", 42))
+	err = fmt.Errorf("cannot ship: %w", err)
+	err = apperror.WithAttrs(err, slog.String("user_id", "alice"))
+
+	// This is what slog.Any("error", err) will log.
+	for _, attr := range apperror.LogValue(err).Group() {
+		fmt.Println(attr)
+	}
+
+	// Output:
+	// kind=not_found
+	// message=cannot ship: order not found
+	// attrs=[user_id=alice order_id=42]
+}
+
+func TestWithAttrs_returns_nil_for_nil(t *testing.T) {
+	err := apperror.WithAttrs(nil, slog.Int("id", 1))
+	if err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestWithAttrs_preserves_kind(t *testing.T) {
+	for _, tc := range testCases {
+		t.Run(tc.Name(), func(t *testing.T) {
+			err := tc.stringConstructor(message)
+			err = apperror.WithAttrs(err, slog.Int("id", 1))
+			assertErrorKind(t, err, tc.kind, tc.matcher)
+		})
+	}
+}
+
+func TestWithAttrs_preserves_message(t *testing.T) {
+	err := apperror.WithAttrs(errors.New(message), slog.Int("id", 1))
+	assertTestMessage(t, err)
+}
+
+func TestWithAttrs_preserves_the_wrapped_error(t *testing.T) {
+	original := errors.New(message)
+	err := apperror.WithAttrs(original, slog.Int("id", 1))
+	if !errors.Is(err, original) {
+		t.Error("expected the original error to be in the chain")
+	}
+}
+
+func TestAttrs_collects_attributes_along_the_chain(t *testing.T) {
+	err := apperror.WithAttrs(errors.New(message), slog.Int("inner", 1))
+	err = fmt.Errorf("wrapped: %w", err)
+	err = apperror.WithAttrs(err, slog.Int("outer", 2))
+	err = errors.Join(
+		err,
+		apperror.WithAttrs(errors.New(message), slog.Int("joined", 3)),
+	)
+
+	got := apperror.Attrs(err)
+	expected := []slog.Attr{
+		slog.Int("outer", 2),
+		slog.Int("inner", 1),
+		slog.Int("joined", 3),
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if !got[i].Equal(expected[i]) {
+			t.Errorf("expected %v, got %v", expected[i], got[i])
+		}
+	}
+}
+
+func TestAttrs_returns_nil_without_attributes(t *testing.T) {
+	for _, err := range []error{nil, errors.New(message)} {
+		if got := apperror.Attrs(err); got != nil {
+			t.Errorf("expected nil, got %v", got)
+		}
+	}
+}
+
+func TestWithAttrs_is_a_log_valuer(t *testing.T) {
+	err := apperror.WithAttrs(apperror.Conflict(message), slog.Int("id", 1))
+
+	var entry struct {
+		Error map[string]any
+	}
+	logError(t, nil, err, &entry)
+
+	expected := map[string]any{
+		"kind":    "conflict",
+		"message": message,
+		"attrs":   map[string]any{"id": 1.0},
+	}
+	if fmt.Sprint(entry.Error) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, entry.Error)
+	}
+}
+
+func TestWithAttrs_is_not_a_log_valuer_once_wrapped(t *testing.T) {
+	err := apperror.WithAttrs(apperror.Conflict(message), slog.Int("id", 1))
+	err = fmt.Errorf("wrapped: %w", err)
+
+	var entry struct {
+		Error string
+	}
+	logError(t, nil, err, &entry)
+
+	if expected := "wrapped: " + message; entry.Error != expected {
+		t.Errorf("expected %v, got %v", expected, entry.Error)
+	}
+}
+
+func TestReplaceAttr_logs_wrapped_attributes(t *testing.T) {
+	err := apperror.WithAttrs(apperror.Conflict(message), slog.Int("id", 1))
+	err = fmt.Errorf("wrapped: %w", err)
+
+	var entry struct {
+		Error map[string]any
+	}
+	logError(t, apperror.ReplaceAttr, err, &entry)
+
+	expected := map[string]any{
+		"kind":    "conflict",
+		"message": "wrapped: " + message,
+		"attrs":   map[string]any{"id": 1.0},
+	}
+	if fmt.Sprint(entry.Error) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, entry.Error)
+	}
+}
+
+func TestReplaceAttr_preserves_other_attributes(t *testing.T) {
+	attr := slog.String("key", "value")
+	if got := apperror.ReplaceAttr(nil, attr); !got.Equal(attr) {
+		t.Errorf("expected %v, got %v", attr, got)
+	}
+}
+
+func TestLogValue_omits_attrs_if_there_are_none(t *testing.T) {
+	for _, attr := range apperror.LogValue(errors.New(message)).Group() {
+		if attr.Key == "attrs" {
+			t.Error("unexpected attrs:", attr)
+		}
+	}
+}
+
+func TestLogValue_of_nil_is_OK(t *testing.T) {
+	v := apperror.LogValue(nil)
+	attrs := v.Group()
+	if len(attrs) != 1 || attrs[0].Value.String() != "ok" {
+		t.Errorf("unexpected value: %v", v)
+	}
+}
+
+// logError logs err with a JSON handler and decodes the entry.
+func logError(
+	t *testing.T,
+	replaceAttr func(groups []string, a slog.Attr) slog.Attr,
+	err error,
+	entry any,
+) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: replaceAttr,
+	}))
+	logger.Error("failed", slog.Any("error", err))
+
+	if err := json.Unmarshal(buf.Bytes(), entry); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+}
@@ -0,0 +1,119 @@
+package apperror
+
+// Boundary declares how the kinds of errors change when they cross a layer
+// boundary. Kinds that are not present in the map are preserved.
+//
+// For example, a NotFoundError returned by a downstream dependency does not
+// mean that the resource requested by our own clients does not exist. It
+// means that our service is broken:
+//
+//	var downstream = apperror.Boundary{
+//		apperror.NotFoundError:   apperror.UnknownError,
+//		apperror.ValidationError: apperror.UnknownError,
+//	}
+//
+//	err = downstream.Cross(err)
+type Boundary map[Kind]Kind
+
+// Cross translates the kind of an error according to the Boundary.
+// It returns nil for nil errors, and also if the translated kind is OK.
+//
+// The original error is preserved in the chain, e.g., for logging, but both
+// KindOf and the matchers (IsNotFound, etc.) will report the translated kind.
+// Errors whose kind does not change are returned as is.
+func (b Boundary) Cross(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	kind := KindOf(err)
+	translated, ok := b[kind]
+	if !ok || translated == kind {
+		return err
+	}
+
+	if translated == OK {
+		return nil
+	}
+
+	return &translatedError{
+		error: err,
+		kind:  translated,
+	}
+}
+
+// translatedError overrides the kind of the error that it wraps.
+//
+// It implements all matcher methods so that matchers stop at it instead of
+// finding the original kind further down the chain.
+type translatedError struct {
+	error
+	kind Kind
+}
+
+func (e *translatedError) Unwrap() error {
+	return e.error
+}
+
+// PublicMessage returns the public message of the original error, so that
+// the context added to it downstream is never exposed. Unknown errors are
+// never public by default, so translating an error into one hides it.
+func (e *translatedError) PublicMessage() string {
+	if e.kind == UnknownError {
+		return ""
+	}
+
+	return PublicMessage(e.error)
+}
+
+func (e *translatedError) Kind() Kind {
+	return e.kind
+}
+
+func (e *translatedError) Validation() bool {
+	return e.kind == ValidationError
+}
+
+func (e *translatedError) Unauthorized() bool {
+	return e.kind == UnauthorizedError
+}
+
+func (e *translatedError) Forbidden() bool {
+	return e.kind == ForbiddenError
+}
+
+func (e *translatedError) NotFound() bool {
+	return e.kind == NotFoundError
+}
+
+func (e *translatedError) Conflict() bool {
+	return e.kind == ConflictError
+}
+
+func (e *translatedError) PreconditionFailed() bool {
+	return e.kind == PreconditionFailedError
+}
+
+func (e *translatedError) TooManyRequests() bool {
+	return e.kind == TooManyRequestsError
+}
+
+func (e *translatedError) Timeout() bool {
+	return e.kind == TimeoutError
+}
+
+func (e *translatedError) Unimplemented() bool {
+	return e.kind == UnimplementedError
+}
+
+func (e *translatedError) Unavailable() bool {
+	return e.kind == UnavailableError
+}
+
+func (e *translatedError) Canceled() bool {
+	return e.kind == CanceledError
+}
+
+func (e *translatedError) Gone() bool {
+	return e.kind == GoneError
+}
@@ -0,0 +1,106 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleBoundary() {
+	downstream := apperror.Boundary{
+		apperror.NotFoundError:   apperror.UnknownError,
+		apperror.ValidationError: apperror.UnknownError,
+	}
+
+	original := apperror.NotFound("user not found")
+	err := downstream.Cross(original)
+
+	fmt.Println("Kind:", apperror.KindOf(err))
+	fmt.Println("Not found:", apperror.IsNotFound(err))
+	fmt.Println("Original preserved:", errors.Is(err, original))
+	fmt.Println("Timeouts unchanged:", apperror.KindOf(
+		downstream.Cross(apperror.Timeout("timed out")),
+	))
+
+	// Output:
+	// Kind: UnknownError
+	// Not found: false
+	// Original preserved: true
+	// Timeouts unchanged: TimeoutError
+}
+
+func TestBoundary_Cross_returns_nil_for_nil(t *testing.T) {
+	b := apperror.Boundary{apperror.OK: apperror.UnknownError}
+	if err := b.Cross(nil); err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestBoundary_Cross_translates_to_every_kind(t *testing.T) {
+	for _, tc := range testCases {
+		t.Run(tc.Name(), func(t *testing.T) {
+			b := apperror.Boundary{apperror.GoneError: tc.kind}
+			err := b.Cross(apperror.Gone(message))
+
+			assertErrorKind(t, err, tc.kind, tc.matcher)
+			assertTestMessage(t, err)
+		})
+	}
+}
+
+func TestBoundary_Cross_hides_the_original_kind(t *testing.T) {
+	for _, tc := range testCases {
+		if tc.kind == apperror.UnknownError {
+			// Special case: nothing to hide.
+			continue
+		}
+
+		t.Run(tc.Name(), func(t *testing.T) {
+			b := apperror.Boundary{tc.kind: apperror.UnknownError}
+			err := b.Cross(tc.stringConstructor(message))
+
+			if tc.matcher(err) {
+				t.Error("the original kind was matched")
+			}
+			if !apperror.IsUnknown(err) {
+				t.Error("the translated kind was not matched")
+			}
+		})
+	}
+}
+
+func TestBoundary_Cross_preserves_unlisted_kinds(t *testing.T) {
+	b := apperror.Boundary{apperror.NotFoundError: apperror.UnknownError}
+	original := apperror.Conflict(message)
+
+	if err := b.Cross(original); err != original {
+		t.Errorf("expected %v, got %v", original, err)
+	}
+}
+
+func TestBoundary_Cross_preserves_the_original_error(t *testing.T) {
+	b := apperror.Boundary{apperror.UnknownError: apperror.UnavailableError}
+	original := errors.New(message)
+	err := b.Cross(fmt.Errorf("wrapped: %w", original))
+
+	if !errors.Is(err, original) {
+		t.Error("the original error is not in the chain")
+	}
+}
+
+func TestBoundary_Cross_can_discard_errors(t *testing.T) {
+	b := apperror.Boundary{apperror.NotFoundError: apperror.OK}
+	if err := b.Cross(apperror.NotFound(message)); err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestBoundary_Cross_hides_the_public_message(t *testing.T) {
+	b := apperror.Boundary{apperror.NotFoundError: apperror.UnknownError}
+	err := b.Cross(apperror.NotFound(message))
+
+	if got := apperror.PublicMessage(err); got != "" {
+		t.Errorf(`expected "", got "%v"`, got)
+	}
+}
@@ -0,0 +1,46 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+)
+
+type canceledError struct {
+	error
+}
+
+func (e canceledError) Canceled() bool {
+	return true
+}
+
+func (e canceledError) Kind() Kind {
+	return CanceledError
+}
+
+// Canceled creates a new canceled error.
+func Canceled(msg string) error {
+	return canceledError{error: errors.New(msg)}
+}
+
+// Canceledf creates a new canceled error.
+func Canceledf(msg string, a ...any) error {
+	return canceledError{error: fmt.Errorf(msg, a...)}
+}
+
+// AsCanceled wraps an existing error as a canceled error.
+// It returns nil for nil errors.
+func AsCanceled(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return canceledError{error: err}
+}
+
+// IsCanceled matches canceled errors.
+func IsCanceled(err error) bool {
+	var target interface {
+		Canceled() bool
+	}
+	return errors.As(err, &target) && target.Canceled()
+}
@@ -0,0 +1,64 @@
+package apperror
+
+import (
+	"errors"
+)
+
+// Challenge tells clients how to authenticate, typically for errors of kind
+// UnauthorizedError. It follows the challenges of the WWW-Authenticate
+// header of HTTP, as defined by RFC 9110, with the parameters of RFC 6750.
+type Challenge struct {
+	// Scheme is the authentication scheme, e.g., "Bearer".
+	Scheme string
+
+	// Realm optionally describes the protection space, e.g., "example".
+	Realm string
+
+	// Error optionally identifies the cause, e.g., "invalid_token".
+	Error string
+
+	// ErrorDescription optionally explains the cause to developers.
+	ErrorDescription string
+}
+
+// challengeError attaches a Challenge to an error without changing its kind
+// or message.
+type challengeError struct {
+	error
+	challenge Challenge
+}
+
+func (e *challengeError) Unwrap() error {
+	return e.error
+}
+
+func (e *challengeError) Challenge() Challenge {
+	return e.challenge
+}
+
+// WithChallenge attaches an authentication Challenge to an error.
+// The kind and the message of the error are preserved.
+// It returns nil for nil errors.
+func WithChallenge(err error, challenge Challenge) error {
+	if err == nil {
+		return nil
+	}
+
+	return &challengeError{
+		error:     err,
+		challenge: challenge,
+	}
+}
+
+// ChallengeOf returns the outermost Challenge attached with WithChallenge.
+// The boolean is false if the error does not carry one.
+func ChallengeOf(err error) (Challenge, bool) {
+	var target interface {
+		Challenge() Challenge
+	}
+	if !errors.As(err, &target) {
+		return Challenge{}, false
+	}
+
+	return target.Challenge(), true
+}
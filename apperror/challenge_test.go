@@ -0,0 +1,75 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleWithChallenge() {
+	err := apperror.Unauthorized("the access token expired")
+	err = apperror.WithChallenge(err, apperror.Challenge{
+		Scheme: "Bearer",
+		Realm:  "example",
+		Error:  "invalid_token",
+	})
+	err = fmt.Errorf("cannot create order: %w", err)
+
+	if challenge, ok := apperror.ChallengeOf(err); ok {
+		fmt.Println(challenge.Scheme, challenge.Error)
+	}
+
+	// Output: Bearer invalid_token
+}
+
+func TestWithChallenge_returns_nil_for_nil(t *testing.T) {
+	err := apperror.WithChallenge(nil, testChallenge)
+	if err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestWithChallenge_preserves_kind(t *testing.T) {
+	for _, tc := range testCases {
+		t.Run(tc.Name(), func(t *testing.T) {
+			err := tc.stringConstructor(message)
+			err = apperror.WithChallenge(err, testChallenge)
+			assertErrorKind(t, err, tc.kind, tc.matcher)
+		})
+	}
+}
+
+func TestWithChallenge_preserves_message(t *testing.T) {
+	err := apperror.WithChallenge(errors.New(message), testChallenge)
+	assertTestMessage(t, err)
+}
+
+func TestChallengeOf_returns_outermost_challenge(t *testing.T) {
+	inner := apperror.Challenge{Scheme: "Basic"}
+	err := apperror.WithChallenge(apperror.Unauthorized(message), inner)
+	err = apperror.WithChallenge(err, testChallenge)
+
+	got, ok := apperror.ChallengeOf(err)
+	if !ok {
+		t.Fatal("missing challenge")
+	}
+	if got != testChallenge {
+		t.Errorf("expected %v, got %v", testChallenge, got)
+	}
+}
+
+func TestChallengeOf_reports_missing_challenges(t *testing.T) {
+	for _, err := range []error{nil, apperror.Unauthorized(message)} {
+		if _, ok := apperror.ChallengeOf(err); ok {
+			t.Error("unexpected challenge for:", err)
+		}
+	}
+}
+
+var testChallenge = apperror.Challenge{
+	Scheme:           "Bearer",
+	Realm:            "example",
+	Error:            "invalid_token",
+	ErrorDescription: "The access token expired",
+}
@@ -0,0 +1,108 @@
+package apperror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Classifier determines the Kind of errors that do not declare one
+// themselves, such as sentinel errors from other packages.
+// The boolean must be false if the classifier does not recognize the error.
+type Classifier func(err error) (Kind, bool)
+
+// RegisterClassifier adds a Classifier to the registry consulted by KindOf,
+// and therefore by IsFinal, IsUser and IsUnknown.
+//
+// Classifiers are consulted in registration order, after the built-in ones,
+// and the first one that recognizes an error wins. Since classifiers are
+// only consulted for errors that do not declare their own kind, they cannot
+// override the kind of an apperror error.
+//
+// The returned function removes the Classifier from the registry, which is
+// mostly useful in tests. Calling it more than once has no further effect.
+//
+// It is safe to call RegisterClassifier concurrently with KindOf, although
+// it is typically called during initialization.
+func RegisterClassifier(c Classifier) (unregister func()) {
+	if c == nil {
+		panic("classifier cannot be nil")
+	}
+
+	// The pointer identifies the registration, since functions are not
+	// comparable.
+	r := &registration{classify: c}
+	updateRegistry(func(registry []*registration) []*registration {
+		return append(registry, r)
+	})
+
+	return func() {
+		updateRegistry(func(registry []*registration) []*registration {
+			if i := slices.Index(registry, r); i >= 0 {
+				return slices.Delete(registry, i, i+1)
+			}
+
+			return registry
+		})
+	}
+}
+
+// SentinelClassifier returns a Classifier that maps any error that matches
+// the sentinel according to errors.Is to the specified kind.
+func SentinelClassifier(sentinel error, kind Kind) Classifier {
+	return func(err error) (Kind, bool) {
+		return kind, errors.Is(err, sentinel)
+	}
+}
+
+// classify returns the kind determined by the first matching classifier.
+func classify(err error) (Kind, bool) {
+	for _, r := range *classifiers.Load() {
+		if kind, ok := r.classify(err); ok {
+			return kind, true
+		}
+	}
+
+	return UnknownError, false
+}
+
+// updateRegistry replaces the registry with the result of update, which
+// receives a copy of the current one.
+func updateRegistry(update func(registry []*registration) []*registration) {
+	classifiersMutex.Lock()
+	defer classifiersMutex.Unlock()
+
+	// Copy on write, so that readers never need to lock.
+	updated := update(slices.Clone(*classifiers.Load()))
+	classifiers.Store(&updated)
+}
+
+type registration struct {
+	classify Classifier
+}
+
+var (
+	classifiersMutex sync.Mutex
+	classifiers      atomic.Pointer[[]*registration]
+)
+
+func init() {
+	builtins := []Classifier{
+		SentinelClassifier(context.Canceled, CanceledError),
+		SentinelClassifier(context.DeadlineExceeded, TimeoutError),
+		SentinelClassifier(fs.ErrNotExist, NotFoundError),
+		SentinelClassifier(fs.ErrExist, ConflictError),
+		SentinelClassifier(fs.ErrPermission, ForbiddenError),
+		SentinelClassifier(sql.ErrNoRows, NotFoundError),
+	}
+
+	registry := make([]*registration, 0, len(builtins))
+	for _, c := range builtins {
+		registry = append(registry, &registration{classify: c})
+	}
+	classifiers.Store(&registry)
+}
@@ -0,0 +1,123 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func ExampleRegisterClassifier() {
+	// Typically, a package would do this during initialization.
+	errQuotaExceeded := errors.New("quota exceeded")
+	unregister := apperror.RegisterClassifier(apperror.SentinelClassifier(
+		errQuotaExceeded,
+		apperror.TooManyRequestsError,
+	))
+	defer unregister()
+
+	err := fmt.Errorf("cannot upload: %w", errQuotaExceeded)
+	fmt.Println(apperror.KindOf(err))
+
+	// Output: TooManyRequestsError
+}
+
+func TestKindOf_classifies_standard_library_errors(t *testing.T) {
+	_, errOpen := os.Open("/this/file/does/not/exist")
+
+	for _, tc := range []struct {
+		err      error
+		expected apperror.Kind
+	}{
+		{context.Canceled, apperror.CanceledError},
+		{context.DeadlineExceeded, apperror.TimeoutError},
+		{fs.ErrNotExist, apperror.NotFoundError},
+		{fs.ErrExist, apperror.ConflictError},
+		{fs.ErrPermission, apperror.ForbiddenError},
+		{sql.ErrNoRows, apperror.NotFoundError},
+		{errOpen, apperror.NotFoundError},
+	} {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", tc.err)
+			expected := tc.expected
+			if got := apperror.KindOf(err); got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestKindOf_prefers_declared_kinds_over_classifiers(t *testing.T) {
+	err := apperror.AsConflict(sql.ErrNoRows)
+
+	const expected = apperror.ConflictError
+	if got := apperror.KindOf(err); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestRegisterClassifier_affects_IsFinal_and_IsUser(t *testing.T) {
+	errCustom := errors.New("custom error")
+	t.Cleanup(apperror.RegisterClassifier(apperror.SentinelClassifier(
+		errCustom,
+		apperror.ForbiddenError,
+	)))
+
+	if !apperror.IsFinal(errCustom) {
+		t.Error("expected a final error")
+	}
+	if !apperror.IsUser(errCustom) {
+		t.Error("expected a user error")
+	}
+	if apperror.IsUnknown(errCustom) {
+		t.Error("unexpected unknown error")
+	}
+}
+
+func TestRegisterClassifier_first_match_wins(t *testing.T) {
+	errCustom := errors.New("custom error")
+	t.Cleanup(apperror.RegisterClassifier(apperror.SentinelClassifier(
+		errCustom,
+		apperror.GoneError,
+	)))
+	t.Cleanup(apperror.RegisterClassifier(apperror.SentinelClassifier(
+		errCustom,
+		apperror.ConflictError,
+	)))
+
+	const expected = apperror.GoneError
+	if got := apperror.KindOf(errCustom); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestRegisterClassifier_can_be_undone(t *testing.T) {
+	errCustom := errors.New("custom error")
+	unregister := apperror.RegisterClassifier(apperror.SentinelClassifier(
+		errCustom,
+		apperror.GoneError,
+	))
+	unregister()
+	unregister()
+
+	if !apperror.IsUnknown(errCustom) {
+		t.Error("unexpected kind:", apperror.KindOf(errCustom))
+	}
+	if apperror.KindOf(sql.ErrNoRows) != apperror.NotFoundError {
+		t.Error("a built-in classifier was removed")
+	}
+}
+
+func TestRegisterClassifier_panics_for_nil(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("missing expected panic")
+		}
+	}()
+
+	apperror.RegisterClassifier(nil)
+}
@@ -0,0 +1,46 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+)
+
+type goneError struct {
+	error
+}
+
+func (e goneError) Gone() bool {
+	return true
+}
+
+func (e goneError) Kind() Kind {
+	return GoneError
+}
+
+// Gone creates a new gone error.
+func Gone(msg string) error {
+	return goneError{error: errors.New(msg)}
+}
+
+// Gonef creates a new gone error.
+func Gonef(msg string, a ...any) error {
+	return goneError{error: fmt.Errorf(msg, a...)}
+}
+
+// AsGone wraps an existing error as a gone error.
+// It returns nil for nil errors.
+func AsGone(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return goneError{error: err}
+}
+
+// IsGone matches gone errors.
+func IsGone(err error) bool {
+	var target interface {
+		Gone() bool
+	}
+	return errors.As(err, &target) && target.Gone()
+}
@@ -0,0 +1,184 @@
+package apperror
+
+import (
+	"strings"
+)
+
+// joinError aggregates multiple errors and their kinds.
+//
+// It implements all matcher methods so that matchers agree with KindOf
+// instead of matching individual errors.
+type joinError struct {
+	errs []error
+	kind Kind
+}
+
+func (e *joinError) Error() string {
+	// Mimic errors.Join.
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// PublicMessage joins the public messages of the errors, skipping empty
+// ones, so that the internal messages of the errors are never exposed.
+func (e *joinError) PublicMessage() string {
+	msgs := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		if msg := PublicMessage(err); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
+
+func (e *joinError) Kind() Kind {
+	return e.kind
+}
+
+// Violations collects the violations of all the errors, so that they are
+// not limited to those of the first validation error.
+func (e *joinError) Violations() []Violation {
+	var violations []Violation
+	for _, err := range e.errs {
+		violations = append(violations, Violations(err)...)
+	}
+
+	return violations
+}
+
+func (e *joinError) Validation() bool {
+	return e.kind == ValidationError
+}
+
+func (e *joinError) Unauthorized() bool {
+	return e.kind == UnauthorizedError
+}
+
+func (e *joinError) Forbidden() bool {
+	return e.kind == ForbiddenError
+}
+
+func (e *joinError) NotFound() bool {
+	return e.kind == NotFoundError
+}
+
+func (e *joinError) Conflict() bool {
+	return e.kind == ConflictError
+}
+
+func (e *joinError) PreconditionFailed() bool {
+	return e.kind == PreconditionFailedError
+}
+
+func (e *joinError) TooManyRequests() bool {
+	return e.kind == TooManyRequestsError
+}
+
+func (e *joinError) Timeout() bool {
+	return e.kind == TimeoutError
+}
+
+func (e *joinError) Unimplemented() bool {
+	return e.kind == UnimplementedError
+}
+
+func (e *joinError) Unavailable() bool {
+	return e.kind == UnavailableError
+}
+
+func (e *joinError) Canceled() bool {
+	return e.kind == CanceledError
+}
+
+func (e *joinError) Gone() bool {
+	return e.kind == GoneError
+}
+
+// Join returns an error that wraps the given errors, like errors.Join, but
+// with a well-defined kind. Nil errors are discarded. If all errors are nil,
+// Join returns nil.
+//
+// The kind of the joined error follows this precedence policy:
+//
+//   - If all errors have the same kind, that kind is preserved.
+//   - Otherwise, if all errors are both final and attributable to the user
+//     (see IsFinal and IsUser), the kind is ValidationError. This mirrors
+//     how an unspecific HTTP 4xx is best described as a validation error.
+//   - Otherwise, the kind is UnknownError. This is the conservative choice:
+//     it is neither final nor attributable to the user, so callers will
+//     neither give up on retrying nor blame the user prematurely.
+//
+// Matchers such as IsNotFound agree with KindOf, so they only match the
+// kind of the joined error. Use GroupByKind to inspect individual errors.
+// Violations returns the violations of all the joined errors.
+func Join(errs ...error) error {
+	joined := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			joined = append(joined, err)
+		}
+	}
+
+	if len(joined) == 0 {
+		return nil
+	}
+
+	return &joinError{
+		errs: joined,
+		kind: joinedKind(joined),
+	}
+}
+
+// GroupByKind groups the errors wrapped by a joined error by their kinds.
+// It supports both Join and errors.Join. Any other error is returned as the
+// only member of its own kind. It returns nil for nil errors.
+func GroupByKind(err error) map[Kind][]error {
+	if err == nil {
+		return nil
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return map[Kind][]error{KindOf(err): {err}}
+	}
+
+	groups := make(map[Kind][]error)
+	for _, child := range joined.Unwrap() {
+		if child == nil {
+			continue
+		}
+
+		kind := KindOf(child)
+		groups[kind] = append(groups[kind], child)
+	}
+
+	return groups
+}
+
+func joinedKind(errs []error) Kind {
+	kind := KindOf(errs[0])
+	sameKind := true
+	finalUserErrors := true
+	for _, err := range errs {
+		sameKind = sameKind && KindOf(err) == kind
+		finalUserErrors = finalUserErrors && IsFinal(err) && IsUser(err)
+	}
+
+	switch {
+	case sameKind:
+		return kind
+	case finalUserErrors:
+		return ValidationError
+	default:
+		return UnknownError
+	}
+}
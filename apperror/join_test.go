@@ -0,0 +1,187 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func ExampleJoin() {
+	err := apperror.Join(
+		apperror.NotFound("item 1 not found"),
+		apperror.Unknown("database failure"),
+		apperror.NotFound("item 3 not found"),
+	)
+
+	fmt.Println("Kind:", apperror.KindOf(err))
+	groups := apperror.GroupByKind(err)
+	for _, kind := range apperror.KindValues() {
+		for _, child := range groups[kind] {
+			fmt.Printf("- %v: %v\n", kind, child)
+		}
+	}
+
+	// Output:
+	// Kind: UnknownError
+	// - UnknownError: database failure
+	// - NotFoundError: item 1 not found
+	// - NotFoundError: item 3 not found
+}
+
+func TestJoin_returns_nil_if_all_errors_are_nil(t *testing.T) {
+	for _, errs := range [][]error{nil, {nil}, {nil, nil}} {
+		if err := apperror.Join(errs...); err != nil {
+			t.Error("expected nil, got:", err)
+		}
+	}
+}
+
+func TestJoin_discards_nil_errors(t *testing.T) {
+	err := apperror.Join(nil, apperror.NotFound(message), nil)
+	assertTestMessage(t, err)
+
+	groups := apperror.GroupByKind(err)
+	if len(groups) != 1 || len(groups[apperror.NotFoundError]) != 1 {
+		t.Errorf("unexpected groups: %v", groups)
+	}
+}
+
+func TestJoin_preserves_the_kind_if_all_kinds_are_equal(t *testing.T) {
+	for _, tc := range testCases {
+		t.Run(tc.Name(), func(t *testing.T) {
+			err := apperror.Join(
+				tc.stringConstructor(message),
+				tc.stringConstructor(message),
+			)
+			assertErrorKind(t, err, tc.kind, tc.matcher)
+		})
+	}
+}
+
+func TestJoin_mixed_final_user_errors_are_validation_errors(t *testing.T) {
+	err := apperror.Join(
+		apperror.NotFound(message),
+		apperror.Conflict(message),
+	)
+
+	const expected = apperror.ValidationError
+	if got := apperror.KindOf(err); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if !apperror.IsValidation(err) {
+		t.Error("expected a validation error")
+	}
+	if !apperror.IsUser(err) {
+		t.Error("expected a user error")
+	}
+	if !apperror.IsFinal(err) {
+		t.Error("expected a final error")
+	}
+}
+
+func TestJoin_matchers_follow_the_kind(t *testing.T) {
+	err := apperror.Join(
+		apperror.Validation(message),
+		apperror.Timeout(message),
+	)
+
+	if apperror.IsValidation(err) {
+		t.Error("unexpected validation error")
+	}
+	if apperror.IsTimeout(err) {
+		t.Error("unexpected timeout error")
+	}
+}
+
+func TestJoin_collects_violations(t *testing.T) {
+	first := apperror.Violation{Field: "name", Reason: "REQUIRED"}
+	second := apperror.Violation{Field: "age", Reason: "NOT_POSITIVE"}
+	err := apperror.Join(
+		apperror.WithViolations(apperror.Validation(message), first),
+		apperror.NotFound(message),
+		apperror.WithViolations(apperror.Validation(message), second),
+	)
+
+	expected := []apperror.Violation{first, second}
+	if got := apperror.Violations(err); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestJoin_other_mixed_errors_are_unknown(t *testing.T) {
+	for _, other := range []error{
+		errors.New(message),
+		apperror.Unknown(message),
+		apperror.Timeout(message),
+		apperror.TooManyRequests(message),
+		apperror.Unimplemented(message),
+	} {
+		t.Run(apperror.KindOf(other).String(), func(t *testing.T) {
+			err := apperror.Join(apperror.NotFound(message), other)
+
+			const expected = apperror.UnknownError
+			if got := apperror.KindOf(err); got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+			if apperror.IsUser(err) {
+				t.Error("unexpected user error")
+			}
+			if apperror.IsFinal(err) {
+				t.Error("unexpected final error")
+			}
+		})
+	}
+}
+
+func TestJoin_preserves_children(t *testing.T) {
+	first := apperror.NotFound(message)
+	second := errors.New(message)
+	err := fmt.Errorf("wrapped: %w", apperror.Join(first, second))
+
+	if !errors.Is(err, first) || !errors.Is(err, second) {
+		t.Error("expected children to be in the chain")
+	}
+}
+
+func TestJoin_joins_messages_like_errors_Join(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+
+	expected := errors.Join(first, second).Error()
+	if got := apperror.Join(first, second).Error(); got != expected {
+		t.Errorf(`expected "%v", got "%v"`, expected, got)
+	}
+}
+
+func TestGroupByKind_supports_errors_Join(t *testing.T) {
+	err := errors.Join(
+		apperror.NotFound(message),
+		apperror.Timeout(message),
+		apperror.NotFound(message),
+	)
+
+	groups := apperror.GroupByKind(err)
+	if got := len(groups[apperror.NotFoundError]); got != 2 {
+		t.Errorf("expected 2 not found errors, got %v", got)
+	}
+	if got := len(groups[apperror.TimeoutError]); got != 1 {
+		t.Errorf("expected 1 timeout error, got %v", got)
+	}
+}
+
+func TestGroupByKind_of_non_joined_errors(t *testing.T) {
+	err := apperror.Conflict(message)
+
+	groups := apperror.GroupByKind(err)
+	if len(groups) != 1 || groups[apperror.ConflictError][0] != err {
+		t.Errorf("unexpected groups: %v", groups)
+	}
+}
+
+func TestGroupByKind_of_nil_is_nil(t *testing.T) {
+	if groups := apperror.GroupByKind(nil); groups != nil {
+		t.Errorf("expected nil, got %v", groups)
+	}
+}
@@ -4,6 +4,7 @@ package apperror
 
 import (
 	"errors"
+	"fmt"
 )
 
 // Kind implies semantic connotations about an error. In most cases, knowing
@@ -11,7 +12,8 @@ import (
 // and there is no need to consider the exact error type or message.
 //
 // The numerical values of these constants are not guaranteed to be stable
-// and therefore must not be relied on.
+// and therefore must not be relied on. Use the text encoding instead, which
+// is stable and supports encoding/json and similar packages.
 type Kind int
 
 const (
@@ -25,6 +27,10 @@ const (
 	PreconditionFailedError
 	TooManyRequestsError
 	TimeoutError
+	UnimplementedError
+	UnavailableError
+	CanceledError
+	GoneError
 )
 
 // KindValues returns the set of known values of Kind.
@@ -40,12 +46,20 @@ func KindValues() []Kind {
 		PreconditionFailedError,
 		TooManyRequestsError,
 		TimeoutError,
+		UnimplementedError,
+		UnavailableError,
+		CanceledError,
+		GoneError,
 	}
 }
 
 // KindOf returns the Kind of an error.
 // If the error is nil, it will return OK.
 //
+// Errors that declare their own kind, such as those created by this package,
+// are detected first. Otherwise, the registered classifiers are consulted
+// (see RegisterClassifier). Errors that remain unrecognized are unknown.
+//
 // This is usually faster than calling multiple error kind matchers.
 func KindOf(err error) Kind {
 	// While not essential, supporting OK allows user code to handle
@@ -62,10 +76,71 @@ func KindOf(err error) Kind {
 		return kinder.Kind()
 	}
 
-	// The Go standard library uses Timeout, so check it first.
+	// Detect third-party and standard library errors.
+	if kind, ok := classify(err); ok {
+		return kind
+	}
+
+	// Some errors of the Go standard library, e.g., net.Error, declare
+	// a Timeout method instead of a kind.
 	if IsTimeout(err) {
 		return TimeoutError
 	}
 
 	return UnknownError
 }
+
+// ParseKind parses the stable text encoding of a Kind, e.g., "not_found".
+// It returns a validation error if the name is not recognized.
+func ParseKind(name string) (Kind, error) {
+	for kind, kindName := range kindNames {
+		if name == kindName {
+			return kind, nil
+		}
+	}
+
+	return UnknownError, Validationf("unknown error kind: %q", name)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns a stable snake_case name, e.g., "not_found".
+func (i Kind) MarshalText() ([]byte, error) {
+	name, ok := kindNames[i]
+	if !ok {
+		return nil, fmt.Errorf("cannot marshal invalid kind: %v", i)
+	}
+
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts the names returned by MarshalText.
+func (i *Kind) UnmarshalText(text []byte) error {
+	kind, err := ParseKind(string(text))
+	if err != nil {
+		return err
+	}
+
+	*i = kind
+	return nil
+}
+
+// kindNames contains the stable text encoding of each Kind.
+// These names must never change, since they may have been persisted or sent
+// over the network.
+var kindNames = map[Kind]string{
+	OK:                      "ok",
+	UnknownError:            "unknown",
+	ValidationError:         "validation",
+	UnauthorizedError:       "unauthorized",
+	ForbiddenError:          "forbidden",
+	NotFoundError:           "not_found",
+	ConflictError:           "conflict",
+	PreconditionFailedError: "precondition_failed",
+	TooManyRequestsError:    "too_many_requests",
+	TimeoutError:            "timeout",
+	UnimplementedError:      "unimplemented",
+	UnavailableError:        "unavailable",
+	CanceledError:           "canceled",
+	GoneError:               "gone",
+}
@@ -18,15 +18,20 @@ func _() {
 	_ = x[PreconditionFailedError-7]
 	_ = x[TooManyRequestsError-8]
 	_ = x[TimeoutError-9]
+	_ = x[UnimplementedError-10]
+	_ = x[UnavailableError-11]
+	_ = x[CanceledError-12]
+	_ = x[GoneError-13]
 }
 
-const _Kind_name = "OKUnknownErrorValidationErrorUnauthorizedErrorForbiddenErrorNotFoundErrorConflictErrorPreconditionFailedErrorTooManyRequestsErrorTimeoutError"
+const _Kind_name = "OKUnknownErrorValidationErrorUnauthorizedErrorForbiddenErrorNotFoundErrorConflictErrorPreconditionFailedErrorTooManyRequestsErrorTimeoutErrorUnimplementedErrorUnavailableErrorCanceledErrorGoneError"
 
-var _Kind_index = [...]uint8{0, 2, 14, 29, 46, 60, 73, 86, 109, 129, 141}
+var _Kind_index = [...]uint8{0, 2, 14, 29, 46, 60, 73, 86, 109, 129, 141, 159, 175, 188, 197}
 
 func (i Kind) String() string {
-	if i < 0 || i >= Kind(len(_Kind_index)-1) {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Kind_index)-1 {
 		return "Kind(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return _Kind_name[_Kind_index[i]:_Kind_index[i+1]]
+	return _Kind_name[_Kind_index[idx]:_Kind_index[idx+1]]
 }
@@ -2,12 +2,14 @@ package apperror_test
 
 import (
 	"artk.dev/apperror"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 )
 
 func TestKindValues(t *testing.T) {
-	const expected = 10
+	const expected = 14
 	got := len(apperror.KindValues())
 	if expected != got {
 		t.Errorf("expected %v, got %v", expected, got)
@@ -26,3 +28,114 @@ func TestKind_String_contains_unexpected_values(t *testing.T) {
 		t.Error("unexpected value (-1) not included")
 	}
 }
+
+func ExampleKind_MarshalText() {
+	payload := struct {
+		Kind apperror.Kind `json:"kind"`
+	}{
+		Kind: apperror.NotFoundError,
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		fmt.Println("Unexpected error:", err)
+		return
+	}
+
+	fmt.Println(string(encoded))
+
+	// Output: {"kind":"not_found"}
+}
+
+func TestKind_text_encoding_is_reversible(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			text, err := kind.MarshalText()
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			var got apperror.Kind
+			if err := got.UnmarshalText(text); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if got != kind {
+				t.Errorf("expected %v, got %v", kind, got)
+			}
+		})
+	}
+}
+
+func TestKind_text_encoding_is_stable(t *testing.T) {
+	// These names must never change. Only additions are allowed.
+	for kind, expected := range map[apperror.Kind]string{
+		apperror.OK:                      "ok",
+		apperror.UnknownError:            "unknown",
+		apperror.ValidationError:         "validation",
+		apperror.UnauthorizedError:       "unauthorized",
+		apperror.ForbiddenError:          "forbidden",
+		apperror.NotFoundError:           "not_found",
+		apperror.ConflictError:           "conflict",
+		apperror.PreconditionFailedError: "precondition_failed",
+		apperror.TooManyRequestsError:    "too_many_requests",
+		apperror.TimeoutError:            "timeout",
+		apperror.UnimplementedError:      "unimplemented",
+		apperror.UnavailableError:        "unavailable",
+		apperror.CanceledError:           "canceled",
+		apperror.GoneError:               "gone",
+	} {
+		text, err := kind.MarshalText()
+		if err != nil {
+			t.Error("unexpected error:", err)
+		}
+		if got := string(text); got != expected {
+			t.Errorf(`expected "%v", got "%v"`, expected, got)
+		}
+	}
+}
+
+func TestKind_MarshalText_fails_for_invalid_kinds(t *testing.T) {
+	for _, kind := range []apperror.Kind{-1, 1000000} {
+		if _, err := kind.MarshalText(); err == nil {
+			t.Errorf("expected error for %v", kind)
+		}
+	}
+}
+
+func TestKind_JSON_encoding_is_reversible(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			encoded, err := json.Marshal(kind)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			var got apperror.Kind
+			if err := json.Unmarshal(encoded, &got); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if got != kind {
+				t.Errorf("expected %v, got %v", kind, got)
+			}
+		})
+	}
+}
+
+func TestParseKind_rejects_unknown_names(t *testing.T) {
+	for _, name := range []string{"", "NotFoundError", "NOT_FOUND", "5"} {
+		_, err := apperror.ParseKind(name)
+		if !apperror.IsValidation(err) {
+			t.Errorf("%q: expected validation error: %v", name, err)
+		}
+	}
+}
+
+func TestKind_UnmarshalText_preserves_value_on_failure(t *testing.T) {
+	kind := apperror.ConflictError
+	if err := kind.UnmarshalText([]byte("invalid")); err == nil {
+		t.Error("missing expected error")
+	}
+	if kind != apperror.ConflictError {
+		t.Errorf("unexpected change to %v", kind)
+	}
+}
@@ -0,0 +1,100 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// panicError is an unknown error created from a recovered panic.
+type panicError struct {
+	unknownError
+	value     any
+	callStack stack
+}
+
+func (e *panicError) PanicValue() any {
+	return e.value
+}
+
+func (e *panicError) stack() stack {
+	return e.callStack
+}
+
+// Format implements fmt.Formatter. The verb %+v includes the stack trace.
+func (e *panicError) Format(s fmt.State, verb rune) {
+	formatWithStack(s, verb, e, e.callStack)
+}
+
+// FromPanic converts a value returned by recover into an unknown error that
+// carries the panic value and the stack trace. It returns nil for nil.
+//
+// It must be called directly from the deferred function that recovered the
+// panic, so that the stack trace still includes the panicking code:
+//
+//	defer func() {
+//		if v := recover(); v != nil {
+//			err = apperror.FromPanic(v)
+//		}
+//	}()
+func FromPanic(v any) error {
+	if v == nil {
+		return nil
+	}
+
+	return &panicError{
+		unknownError: unknownError{error: fmt.Errorf("panic: %v", v)},
+		value:        v,
+
+		// Skip FromPanic and the deferred function.
+		callStack: skipRuntimeFrames(callers(2)),
+	}
+}
+
+// skipRuntimeFrames removes the frames of the runtime at the top of the
+// stack of a panic, e.g., runtime.gopanic, so that it starts at the
+// panicking code.
+func skipRuntimeFrames(s stack) stack {
+	for len(s) > 0 && isRuntimeFrame(s[0]) {
+		s = s[1:]
+	}
+
+	return s
+}
+
+func isRuntimeFrame(pc uintptr) bool {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		// Inlined calls share the pc of the outermost function.
+		frame, more := frames.Next()
+		if !more {
+			return strings.HasPrefix(frame.Function, "runtime.")
+		}
+	}
+}
+
+// Catch calls fn and returns its error. If fn panics, the panic is recovered
+// and returned as an unknown error, as if by FromPanic.
+func Catch(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = FromPanic(v)
+		}
+	}()
+
+	return fn()
+}
+
+// PanicValue returns the value of a panic recovered by FromPanic or Catch.
+// The boolean is false if the error was not created from a panic.
+func PanicValue(err error) (any, bool) {
+	var target interface {
+		PanicValue() any
+	}
+	if !errors.As(err, &target) {
+		return nil, false
+	}
+
+	return target.PanicValue(), true
+}
@@ -0,0 +1,117 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleCatch() {
+	err := apperror.Catch(func() error {
+		var m map[string]int
+		m["boom"] = 1 // Panics: assignment to entry in nil map.
+		return nil
+	})
+
+	fmt.Println(apperror.KindOf(err))
+	fmt.Println(err)
+
+	// Output:
+	// UnknownError
+	// panic: assignment to entry in nil map
+}
+
+func TestCatch_returns_the_error_of_fn(t *testing.T) {
+	expected := apperror.NotFound(message)
+	err := apperror.Catch(func() error {
+		return expected
+	})
+	if err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestCatch_converts_panics_into_unknown_errors(t *testing.T) {
+	err := apperror.Catch(func() error {
+		panic(message)
+	})
+
+	assertErrorKind(t, err, apperror.UnknownError, apperror.IsUnknown)
+	if got := err.Error(); !strings.Contains(got, message) {
+		t.Errorf(`expected "%v" to contain "%v"`, got, message)
+	}
+}
+
+func TestCatch_preserves_the_panic_value(t *testing.T) {
+	expected := errors.New(message)
+	err := apperror.Catch(func() error {
+		panic(expected)
+	})
+
+	got, ok := apperror.PanicValue(err)
+	if !ok {
+		t.Fatal("missing panic value")
+	}
+	if got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestCatch_captures_the_stack_of_the_panic(t *testing.T) {
+	err := apperror.Catch(panickingFunction)
+
+	assertStackStartsAt(t, err, "apperror_test.panickingFunction")
+}
+
+func TestCatch_skips_runtime_frames(t *testing.T) {
+	err := apperror.Catch(func() error {
+		return runtimeErrorFunction(nil)
+	})
+
+	assertStackStartsAt(t, err, "apperror_test.runtimeErrorFunction")
+}
+
+func TestFromPanic_returns_nil_for_nil(t *testing.T) {
+	if err := apperror.FromPanic(nil); err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestPanicValue_reports_non_panic_errors(t *testing.T) {
+	for _, err := range []error{nil, apperror.Unknown(message)} {
+		if _, ok := apperror.PanicValue(err); ok {
+			t.Error("unexpected panic value for:", err)
+		}
+	}
+}
+
+func TestStackOf_returns_nil_without_stack(t *testing.T) {
+	for _, err := range []error{nil, apperror.Unknown(message)} {
+		if got := apperror.StackOf(err); got != nil {
+			t.Error("unexpected stack for:", err)
+		}
+	}
+}
+
+func panickingFunction() error {
+	panic(message)
+}
+
+func runtimeErrorFunction(m map[string]int) error {
+	m[message]++
+	return nil
+}
+
+func assertStackStartsAt(t *testing.T, err error, function string) {
+	t.Helper()
+
+	stack := apperror.StackOf(err)
+	if len(stack) == 0 {
+		t.Fatal("missing stack")
+	}
+	if got := stack[0].Function; got != "artk.dev/"+function {
+		t.Errorf("expected %v, got %v", function, got)
+	}
+}
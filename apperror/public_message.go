@@ -0,0 +1,75 @@
+package apperror
+
+import (
+	"errors"
+)
+
+// publicMessageError attaches a user-facing message to an error without
+// changing its kind or its internal message.
+type publicMessageError struct {
+	error
+	msg string
+}
+
+func (e *publicMessageError) Unwrap() error {
+	return e.error
+}
+
+func (e *publicMessageError) PublicMessage() string {
+	return e.msg
+}
+
+// WithPublicMessage attaches a message that is safe to show to end users.
+// The kind and the internal message of the error are preserved, so that the
+// full error chain remains available for logging.
+// It returns nil for nil errors.
+func WithPublicMessage(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &publicMessageError{
+		error: err,
+		msg:   msg,
+	}
+}
+
+// PublicMessage returns the part of an error message that is safe to show
+// to end users. It is determined as follows:
+//
+//   - If a message was attached with WithPublicMessage, the outermost one.
+//   - Otherwise, if the error is unknown, an empty string. Unknown errors
+//     are implementation details and are never public by default.
+//   - Otherwise, the message of the error that determines the kind,
+//     excluding any context added by wrapping it afterwards.
+//
+// Errors returned by Join and Boundary.Cross provide their own public
+// message instead: the public messages of the joined errors, one per line,
+// and the public message of the original error, respectively. Errors
+// returned by WithViolations fall back to a generic message when the
+// wrapped error has no public message.
+//
+// Note that wrapping an internal error with a function such as AsNotFound
+// makes its message public. Use WithPublicMessage in that case.
+func PublicMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var explicit interface {
+		PublicMessage() string
+	}
+	if errors.As(err, &explicit) {
+		return explicit.PublicMessage()
+	}
+
+	var kinder interface {
+		error
+		Kind() Kind
+	}
+	if !errors.As(err, &kinder) || kinder.Kind() == UnknownError {
+		return ""
+	}
+
+	return kinder.Error()
+}
@@ -0,0 +1,120 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleWithPublicMessage() {
+	err := errors.New("pq: duplicate key value")
+	err = apperror.AsConflict(err)
+	err = apperror.WithPublicMessage(err, "the email is already in use")
+	err = fmt.Errorf("tenant 42: %w", err)
+
+	fmt.Println("Log:", err)
+	fmt.Println("User:", apperror.PublicMessage(err))
+
+	// Output:
+	// Log: tenant 42: pq: duplicate key value
+	// User: the email is already in use
+}
+
+func TestWithPublicMessage_returns_nil_for_nil(t *testing.T) {
+	if err := apperror.WithPublicMessage(nil, message); err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestWithPublicMessage_preserves_kind(t *testing.T) {
+	for _, tc := range testCases {
+		t.Run(tc.Name(), func(t *testing.T) {
+			err := tc.stringConstructor("internal")
+			err = apperror.WithPublicMessage(err, message)
+			assertErrorKind(t, err, tc.kind, tc.matcher)
+		})
+	}
+}
+
+func TestWithPublicMessage_preserves_internal_message(t *testing.T) {
+	err := apperror.WithPublicMessage(errors.New(message), "public")
+	assertTestMessage(t, err)
+}
+
+func TestPublicMessage_prefers_outermost_explicit_message(t *testing.T) {
+	err := apperror.WithPublicMessage(errors.New(message), "inner")
+	err = apperror.WithPublicMessage(err, "outer")
+
+	const expected = "outer"
+	if got := apperror.PublicMessage(err); got != expected {
+		t.Errorf(`expected "%v", got "%v"`, expected, got)
+	}
+}
+
+func TestPublicMessage_can_be_set_for_unknown_errors(t *testing.T) {
+	err := apperror.WithPublicMessage(errors.New("internal"), message)
+	if got := apperror.PublicMessage(err); got != message {
+		t.Errorf(`expected "%v", got "%v"`, message, got)
+	}
+}
+
+func TestPublicMessage_defaults_to_the_message_of_the_kind(t *testing.T) {
+	for _, tc := range testCases {
+		if tc.kind == apperror.UnknownError {
+			// Special case: unknown errors are never public.
+			continue
+		}
+
+		t.Run(tc.Name(), func(t *testing.T) {
+			err := tc.stringConstructor(message)
+			err = fmt.Errorf("internal context: %w", err)
+			got := apperror.PublicMessage(err)
+			if got != message {
+				t.Errorf(
+					`expected "%v", got "%v"`,
+					message,
+					got,
+				)
+			}
+		})
+	}
+}
+
+func TestPublicMessage_of_joined_errors(t *testing.T) {
+	err := apperror.Join(
+		fmt.Errorf("tenant 42 SQL: %w", apperror.NotFound("a")),
+		apperror.Unknown("secret"),
+		apperror.NotFound("b"),
+	)
+
+	const expected = "a\nb"
+	if got := apperror.PublicMessage(err); got != expected {
+		t.Errorf(`expected "%v", got "%v"`, expected, got)
+	}
+}
+
+func TestPublicMessage_of_translated_errors(t *testing.T) {
+	b := apperror.Boundary{apperror.TimeoutError: apperror.UnavailableError}
+	err := b.Cross(fmt.Errorf(
+		"SELECT secret FROM t: %w",
+		apperror.Timeout(message),
+	))
+
+	if got := apperror.PublicMessage(err); got != message {
+		t.Errorf(`expected "%v", got "%v"`, message, got)
+	}
+}
+
+func TestPublicMessage_is_empty_for_unknown_errors(t *testing.T) {
+	for _, err := range []error{
+		nil,
+		errors.New(message),
+		apperror.Unknown(message),
+		fmt.Errorf("wrapped: %w", apperror.Unknown(message)),
+	} {
+		if got := apperror.PublicMessage(err); got != "" {
+			t.Errorf(`expected "", got "%v"`, got)
+		}
+	}
+}
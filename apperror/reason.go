@@ -0,0 +1,60 @@
+package apperror
+
+import (
+	"errors"
+)
+
+// Reason identifies the cause of an error in a stable, machine-readable way.
+//
+// Unlike messages, which are meant for humans and may change at any time,
+// reasons are meant for clients to branch on. Unlike kinds, reasons are
+// specific to a domain.
+type Reason struct {
+	// Code is a stable identifier, e.g., "ORDER_ALREADY_SHIPPED".
+	Code string
+
+	// Domain optionally namespaces the code, e.g., "orders.example.com".
+	Domain string
+}
+
+// reasonError attaches a Reason to an error without changing its kind or
+// message.
+type reasonError struct {
+	error
+	reason Reason
+}
+
+func (e *reasonError) Unwrap() error {
+	return e.error
+}
+
+func (e *reasonError) Reason() Reason {
+	return e.reason
+}
+
+// WithReason attaches a machine-readable Reason to an error.
+// The kind and the message of the error are preserved.
+// It returns nil for nil errors.
+func WithReason(err error, reason Reason) error {
+	if err == nil {
+		return nil
+	}
+
+	return &reasonError{
+		error:  err,
+		reason: reason,
+	}
+}
+
+// ReasonOf returns the outermost Reason attached with WithReason.
+// The boolean is false if the error does not carry one.
+func ReasonOf(err error) (Reason, bool) {
+	var target interface {
+		Reason() Reason
+	}
+	if !errors.As(err, &target) {
+		return Reason{}, false
+	}
+
+	return target.Reason(), true
+}
@@ -0,0 +1,73 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleWithReason() {
+	err := apperror.Conflict("the order has already been shipped")
+	err = apperror.WithReason(err, apperror.Reason{
+		Code:   "ORDER_ALREADY_SHIPPED",
+		Domain: "orders.example.com",
+	})
+	err = fmt.Errorf("cannot cancel order: %w", err)
+
+	if reason, ok := apperror.ReasonOf(err); ok {
+		fmt.Println(reason.Domain, reason.Code)
+	}
+
+	// Output: orders.example.com ORDER_ALREADY_SHIPPED
+}
+
+func TestWithReason_returns_nil_for_nil(t *testing.T) {
+	if err := apperror.WithReason(nil, exampleReason); err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestWithReason_preserves_kind(t *testing.T) {
+	for _, tc := range testCases {
+		t.Run(tc.Name(), func(t *testing.T) {
+			err := tc.stringConstructor(message)
+			err = apperror.WithReason(err, exampleReason)
+			assertErrorKind(t, err, tc.kind, tc.matcher)
+		})
+	}
+}
+
+func TestWithReason_preserves_message(t *testing.T) {
+	err := apperror.WithReason(errors.New(message), exampleReason)
+	assertTestMessage(t, err)
+}
+
+func TestReasonOf_returns_the_outermost_reason(t *testing.T) {
+	err := apperror.WithReason(errors.New(message), apperror.Reason{
+		Code: "INNER",
+	})
+	err = fmt.Errorf("wrapped: %w", err)
+	err = apperror.WithReason(err, exampleReason)
+
+	got, ok := apperror.ReasonOf(err)
+	if !ok {
+		t.Fatal("missing reason")
+	}
+	if got != exampleReason {
+		t.Errorf("expected %v, got %v", exampleReason, got)
+	}
+}
+
+func TestReasonOf_reports_missing_reasons(t *testing.T) {
+	for _, err := range []error{nil, apperror.Conflict(message)} {
+		if _, ok := apperror.ReasonOf(err); ok {
+			t.Error("unexpected reason for:", err)
+		}
+	}
+}
+
+var exampleReason = apperror.Reason{
+	Code:   "ORDER_ALREADY_SHIPPED",
+	Domain: "orders.example.com",
+}
@@ -0,0 +1,51 @@
+package apperror
+
+import (
+	"errors"
+	"time"
+)
+
+// retryAfterError attaches a retry delay to an error without changing its
+// kind or message.
+type retryAfterError struct {
+	error
+	delay time.Duration
+}
+
+func (e *retryAfterError) Unwrap() error {
+	return e.error
+}
+
+func (e *retryAfterError) RetryAfter() time.Duration {
+	return e.delay
+}
+
+// WithRetryAfter attaches a hint about how long to wait before retrying.
+// The kind and the message of the error are preserved.
+// It returns nil for nil errors. Negative delays are treated as zero.
+//
+// The hint is mainly meaningful for errors that are not final, such as
+// TooManyRequestsError and TimeoutError.
+func WithRetryAfter(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	return &retryAfterError{
+		error: err,
+		delay: max(delay, 0),
+	}
+}
+
+// RetryAfter returns the retry delay attached with WithRetryAfter.
+// The boolean is false if the error does not carry one.
+func RetryAfter(err error) (time.Duration, bool) {
+	var target interface {
+		RetryAfter() time.Duration
+	}
+	if !errors.As(err, &target) {
+		return 0, false
+	}
+
+	return target.RetryAfter(), true
+}
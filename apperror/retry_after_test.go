@@ -0,0 +1,61 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleWithRetryAfter() {
+	err := apperror.TooManyRequests("slow down")
+	err = apperror.WithRetryAfter(err, 3*time.Second)
+	err = fmt.Errorf("cannot create order: %w", err)
+
+	if delay, ok := apperror.RetryAfter(err); ok {
+		fmt.Println("Retry after", delay)
+	}
+
+	// Output: Retry after 3s
+}
+
+func TestWithRetryAfter_returns_nil_for_nil(t *testing.T) {
+	if err := apperror.WithRetryAfter(nil, time.Second); err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestWithRetryAfter_preserves_kind(t *testing.T) {
+	for _, tc := range testCases {
+		t.Run(tc.Name(), func(t *testing.T) {
+			err := tc.stringConstructor(message)
+			err = apperror.WithRetryAfter(err, time.Second)
+			assertErrorKind(t, err, tc.kind, tc.matcher)
+		})
+	}
+}
+
+func TestWithRetryAfter_preserves_message(t *testing.T) {
+	err := apperror.WithRetryAfter(errors.New(message), time.Second)
+	assertTestMessage(t, err)
+}
+
+func TestWithRetryAfter_negative_delays_are_zero(t *testing.T) {
+	err := apperror.WithRetryAfter(errors.New(message), -time.Second)
+	delay, ok := apperror.RetryAfter(err)
+	if !ok {
+		t.Fatal("missing retry delay")
+	}
+	if delay != 0 {
+		t.Errorf("expected 0, got %v", delay)
+	}
+}
+
+func TestRetryAfter_reports_missing_delays(t *testing.T) {
+	for _, err := range []error{nil, apperror.Timeout(message)} {
+		if _, ok := apperror.RetryAfter(err); ok {
+			t.Error("unexpected retry delay for:", err)
+		}
+	}
+}
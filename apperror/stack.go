@@ -0,0 +1,139 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync/atomic"
+)
+
+// CaptureStacks globally enables or disables capturing stack traces in
+// Unknown, Unknownf and AsUnknown. It is disabled by default.
+//
+// While disabled, it has no cost beyond an atomic load. Use WithStack to
+// capture stack traces for individual errors regardless of this setting.
+func CaptureStacks(enabled bool) {
+	captureStacks.Store(enabled)
+}
+
+// WithStack attaches the stack trace of the caller to an error.
+// The kind and the message of the error are preserved.
+// It returns nil for nil errors.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &stackError{
+		error:     err,
+		callStack: callers(1),
+	}
+}
+
+// StackOf returns the stack trace captured when an error was created, if
+// any. Stack traces are captured for recovered panics (see FromPanic), by
+// WithStack, and by unknown errors while CaptureStacks is enabled.
+// It returns nil if the error does not carry a stack trace.
+//
+// Errors with stack traces also print them when formatted with %+v.
+func StackOf(err error) []runtime.Frame {
+	var target interface {
+		stack() stack
+	}
+	if !errors.As(err, &target) {
+		return nil
+	}
+
+	return target.stack().frames()
+}
+
+// stackError attaches a stack trace to an error without changing its kind or
+// message.
+type stackError struct {
+	error
+	callStack stack
+}
+
+func (e *stackError) Unwrap() error {
+	return e.error
+}
+
+func (e *stackError) stack() stack {
+	return e.callStack
+}
+
+// Format implements fmt.Formatter. The verb %+v includes the stack trace.
+func (e *stackError) Format(s fmt.State, verb rune) {
+	formatWithStack(s, verb, e, e.callStack)
+}
+
+// withCapturedStack attaches the stack of the caller's caller to an error if
+// CaptureStacks is enabled.
+func withCapturedStack(err error) error {
+	if !captureStacks.Load() {
+		return err
+	}
+
+	return &stackError{
+		error: err,
+
+		// Skip this function and the constructor.
+		callStack: callers(2),
+	}
+}
+
+// formatWithStack implements fmt.Formatter for errors with a stack trace.
+func formatWithStack(s fmt.State, verb rune, err error, st stack) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		_, _ = io.WriteString(s, err.Error())
+		for _, frame := range st.frames() {
+			_, _ = fmt.Fprintf(
+				s,
+				"\n%s\n\t%s:%d",
+				frame.Function,
+				frame.File,
+				frame.Line,
+			)
+		}
+	case verb == 'q':
+		_, _ = fmt.Fprintf(s, "%q", err.Error())
+	default:
+		_, _ = io.WriteString(s, err.Error())
+	}
+}
+
+var captureStacks atomic.Bool
+
+// stack is a compact representation of a stack trace. Symbolization is
+// deferred until the frames are needed, which keeps capturing cheap.
+type stack []uintptr
+
+// callers captures the stack of the calling goroutine. The argument skip is
+// the number of frames to skip, with 0 identifying the caller of callers.
+func callers(skip int) stack {
+	pcs := make([]uintptr, maxStackDepth)
+
+	// Skip runtime.Callers and this function, too.
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+func (s stack) frames() []runtime.Frame {
+	if len(s) == 0 {
+		return nil
+	}
+
+	frames := make([]runtime.Frame, 0, len(s))
+	iterator := runtime.CallersFrames(s)
+	for {
+		frame, more := iterator.Next()
+		frames = append(frames, frame)
+		if !more {
+			return frames
+		}
+	}
+}
+
+const maxStackDepth = 64
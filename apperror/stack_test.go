@@ -0,0 +1,180 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleCaptureStacks() {
+	apperror.CaptureStacks(true)
+	defer apperror.CaptureStacks(false)
+
+	err := apperror.Unknown("something went wrong")
+
+	// The stack is only printed with %+v.
+	fmt.Printf("%v\n", err)
+	fmt.Println(len(apperror.StackOf(err)) > 0)
+
+	// Output:
+	// something went wrong
+	// true
+}
+
+func TestCaptureStacks_is_disabled_by_default(t *testing.T) {
+	for _, err := range unknownConstructors() {
+		if got := apperror.StackOf(err); got != nil {
+			t.Error("unexpected stack for:", err)
+		}
+	}
+}
+
+func TestCaptureStacks_captures_the_caller(t *testing.T) {
+	enableStackCapture(t)
+
+	for _, err := range unknownConstructors() {
+		t.Run(err.Error(), func(t *testing.T) {
+			assertErrorKind(
+				t,
+				err,
+				apperror.UnknownError,
+				apperror.IsUnknown,
+			)
+			assertStackStartsWith(t, err, ".unknownConstructors")
+		})
+	}
+}
+
+func TestWithStack_captures_the_caller(t *testing.T) {
+	err := apperror.WithStack(apperror.NotFound(message))
+
+	assertErrorKind(t, err, apperror.NotFoundError, apperror.IsNotFound)
+	assertTestMessage(t, err)
+	assertStackStartsWith(t, err, ".TestWithStack_captures_the_caller")
+}
+
+func TestWithStack_returns_nil_for_nil(t *testing.T) {
+	if err := apperror.WithStack(nil); err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestWithStack_survives_wrapping(t *testing.T) {
+	err := apperror.WithStack(errors.New(message))
+	err = fmt.Errorf("wrapped: %w", err)
+	if apperror.StackOf(err) == nil {
+		t.Error("missing stack")
+	}
+}
+
+func TestWithStack_formatting(t *testing.T) {
+	err := apperror.WithStack(errors.New(message))
+
+	for format, expected := range map[string]string{
+		"%v": message,
+		"%s": message,
+		"%q": `"` + message + `"`,
+	} {
+		got := fmt.Sprintf(format, err)
+		if got != expected {
+			t.Errorf(
+				"%v: expected %q, got %q",
+				format,
+				expected,
+				got,
+			)
+		}
+	}
+
+	got := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(got, message+"\n") {
+		t.Errorf("missing message in %q", got)
+	}
+	if !strings.Contains(got, "TestWithStack_formatting") {
+		t.Errorf("missing stack in %q", got)
+	}
+}
+
+func TestFromPanic_formatting_includes_stack(t *testing.T) {
+	err := apperror.Catch(panickingFunction)
+	got := fmt.Sprintf("%+v", err)
+	if !strings.Contains(got, "panickingFunction") {
+		t.Errorf("missing stack in %q", got)
+	}
+}
+
+// Constructors of other kinds, which never capture stacks, are the baseline.
+func TestUnknown_does_not_allocate_more_while_disabled(t *testing.T) {
+	baseline := testing.AllocsPerRun(100, func() {
+		sink = apperror.NotFound(message)
+	})
+	got := testing.AllocsPerRun(100, func() {
+		sink = apperror.Unknown(message)
+	})
+
+	if got != baseline {
+		t.Errorf("expected %v allocations, got %v", baseline, got)
+	}
+}
+
+// Compare "disabled" with "baseline" to verify that stack capture is free
+// while disabled. Constructors of other kinds never capture stacks.
+func BenchmarkUnknown(b *testing.B) {
+	b.Run("baseline", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sink = apperror.NotFound(message)
+		}
+	})
+
+	b.Run("disabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sink = apperror.Unknown(message)
+		}
+	})
+
+	b.Run("enabled", func(b *testing.B) {
+		apperror.CaptureStacks(true)
+		defer apperror.CaptureStacks(false)
+
+		b.ReportAllocs()
+		for range b.N {
+			sink = apperror.Unknown(message)
+		}
+	})
+}
+
+// sink prevents the compiler from optimizing away benchmarked code.
+var sink error
+
+func unknownConstructors() []error {
+	return []error{
+		apperror.Unknown("Unknown"),
+		apperror.Unknownf("Unknownf"),
+		apperror.AsUnknown(errors.New("AsUnknown")),
+	}
+}
+
+func enableStackCapture(t *testing.T) {
+	t.Helper()
+
+	apperror.CaptureStacks(true)
+	t.Cleanup(func() {
+		apperror.CaptureStacks(false)
+	})
+}
+
+func assertStackStartsWith(t *testing.T, err error, function string) {
+	t.Helper()
+
+	frames := apperror.StackOf(err)
+	if len(frames) == 0 {
+		t.Fatal("missing stack")
+	}
+	if got := frames[0].Function; !strings.HasSuffix(got, function) {
+		t.Errorf("expected %v, got %v", function, got)
+	}
+}
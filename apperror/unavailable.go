@@ -0,0 +1,46 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+)
+
+type unavailableError struct {
+	error
+}
+
+func (e unavailableError) Unavailable() bool {
+	return true
+}
+
+func (e unavailableError) Kind() Kind {
+	return UnavailableError
+}
+
+// Unavailable creates a new unavailable error.
+func Unavailable(msg string) error {
+	return unavailableError{error: errors.New(msg)}
+}
+
+// Unavailablef creates a new unavailable error.
+func Unavailablef(msg string, a ...any) error {
+	return unavailableError{error: fmt.Errorf(msg, a...)}
+}
+
+// AsUnavailable wraps an existing error as an unavailable error.
+// It returns nil for nil errors.
+func AsUnavailable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return unavailableError{error: err}
+}
+
+// IsUnavailable matches unavailable errors.
+func IsUnavailable(err error) bool {
+	var target interface {
+		Unavailable() bool
+	}
+	return errors.As(err, &target) && target.Unavailable()
+}
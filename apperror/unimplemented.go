@@ -0,0 +1,46 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+)
+
+type unimplementedError struct {
+	error
+}
+
+func (e unimplementedError) Unimplemented() bool {
+	return true
+}
+
+func (e unimplementedError) Kind() Kind {
+	return UnimplementedError
+}
+
+// Unimplemented creates a new unimplemented error.
+func Unimplemented(msg string) error {
+	return unimplementedError{error: errors.New(msg)}
+}
+
+// Unimplementedf creates a new unimplemented error.
+func Unimplementedf(msg string, a ...any) error {
+	return unimplementedError{error: fmt.Errorf(msg, a...)}
+}
+
+// AsUnimplemented wraps an existing error as an unimplemented error.
+// It returns nil for nil errors.
+func AsUnimplemented(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return unimplementedError{error: err}
+}
+
+// IsUnimplemented matches unimplemented errors.
+func IsUnimplemented(err error) bool {
+	var target interface {
+		Unimplemented() bool
+	}
+	return errors.As(err, &target) && target.Unimplemented()
+}
@@ -20,8 +20,10 @@ func (e unknownError) Kind() Kind {
 // While any non-semantic error will be detected as an unknown error, the
 // error returned by this stringConstructor implements the kinder interface and
 // can be checked faster.
+//
+// It captures a stack trace if CaptureStacks is enabled.
 func Unknown(msg string) error {
-	return unknownError{error: errors.New(msg)}
+	return withCapturedStack(unknownError{error: errors.New(msg)})
 }
 
 // Unknownf returns a semantic error of UnknownError.
@@ -29,18 +31,22 @@ func Unknown(msg string) error {
 // While any non-semantic error will be detected as an unknown error, the
 // error returned by this stringConstructor implements the kinder interface and
 // can be checked faster.
+//
+// It captures a stack trace if CaptureStacks is enabled.
 func Unknownf(msg string, a ...any) error {
-	return unknownError{error: fmt.Errorf(msg, a...)}
+	return withCapturedStack(unknownError{error: fmt.Errorf(msg, a...)})
 }
 
 // AsUnknown wraps an existing error as a unknown error.
 // It returns nil for nil errors.
+//
+// It captures a stack trace if CaptureStacks is enabled.
 func AsUnknown(err error) error {
 	if err == nil {
 		return nil
 	}
 
-	return unknownError{error: err}
+	return withCapturedStack(unknownError{error: err})
 }
 
 // IsUnknown matches unknown errors.
@@ -3,6 +3,7 @@ package apperror
 import (
 	"errors"
 	"fmt"
+	"slices"
 )
 
 type validationError struct {
@@ -44,3 +45,68 @@ func IsValidation(err error) bool {
 	}
 	return errors.As(err, &target) && target.Validation()
 }
+
+// Violation describes why a specific input field failed validation.
+type Violation struct {
+	// Field is the path to the offending field, e.g., "items[0].quantity".
+	Field string `json:"field"`
+
+	// Reason is a stable, machine-readable identifier, e.g., "REQUIRED".
+	Reason string `json:"reason"`
+
+	// Message is a human-readable explanation of the violation.
+	Message string `json:"message"`
+}
+
+// violationsError is a validation error with field-level details.
+type violationsError struct {
+	validationError
+	violations []Violation
+}
+
+func (e *violationsError) Unwrap() error {
+	return e.error
+}
+
+func (e *violationsError) Violations() []Violation {
+	return e.violations
+}
+
+// PublicMessage prevents WithViolations from making the message of an
+// internal error public, as AsValidation would.
+func (e *violationsError) PublicMessage() string {
+	if msg := PublicMessage(e.error); msg != "" {
+		return msg
+	}
+
+	return "invalid input"
+}
+
+// WithViolations wraps an existing error as a validation error that carries
+// field-level violations.
+// Its public message is that of err or, if err has none, a generic one.
+// It returns nil for nil errors.
+func WithViolations(err error, violations ...Violation) error {
+	if err == nil {
+		return nil
+	}
+
+	return &violationsError{
+		validationError: validationError{error: err},
+		violations:      slices.Clone(violations),
+	}
+}
+
+// Violations returns the field-level violations of a validation error.
+// It returns nil if the error does not carry any.
+func Violations(err error) []Violation {
+	var target interface {
+		Violations() []Violation
+	}
+	if !errors.As(err, &target) {
+		return nil
+	}
+
+	// Prevent callers from modifying the original error.
+	return slices.Clone(target.Violations())
+}
@@ -0,0 +1,155 @@
+package apperror_test
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func ExampleWithViolations() {
+	err := apperror.WithViolations(
+		apperror.Validation("invalid order"),
+		apperror.Violation{
+			Field:   "items[0].quantity",
+			Reason:  "NOT_POSITIVE",
+			Message: "quantity must be positive",
+		},
+	)
+
+	// Violations survive wrapping.
+	err = fmt.Errorf("cannot place order: %w", err)
+
+	fmt.Println(apperror.KindOf(err))
+	for _, v := range apperror.Violations(err) {
+		fmt.Println(v.Field, v.Reason, v.Message)
+	}
+
+	// Output:
+	// ValidationError
+	// items[0].quantity NOT_POSITIVE quantity must be positive
+}
+
+func TestWithViolations_returns_nil_for_nil(t *testing.T) {
+	err := apperror.WithViolations(nil, exampleViolation)
+	if err != nil {
+		t.Error("expected nil, got:", err)
+	}
+}
+
+func TestWithViolations_is_a_validation_error(t *testing.T) {
+	err := apperror.WithViolations(errors.New(message), exampleViolation)
+	assertErrorKind(
+		t,
+		err,
+		apperror.ValidationError,
+		apperror.IsValidation,
+	)
+}
+
+func TestWithViolations_preserves_message(t *testing.T) {
+	err := apperror.WithViolations(errors.New(message), exampleViolation)
+	assertTestMessage(t, err)
+}
+
+func TestWithViolations_does_not_make_internal_messages_public(
+	t *testing.T,
+) {
+	err := apperror.WithViolations(
+		errors.New("pq: secret"),
+		exampleViolation,
+	)
+
+	if got := apperror.PublicMessage(err); got != "invalid input" {
+		t.Errorf(`expected "invalid input", got "%v"`, got)
+	}
+}
+
+func TestWithViolations_preserves_public_message(t *testing.T) {
+	err := apperror.WithViolations(
+		apperror.Validation(message),
+		exampleViolation,
+	)
+
+	if got := apperror.PublicMessage(err); got != message {
+		t.Errorf(`expected "%v", got "%v"`, message, got)
+	}
+}
+
+func TestWithViolations_survives_wrapping(t *testing.T) {
+	err := apperror.WithViolations(errors.New(message), exampleViolation)
+	err = fmt.Errorf("wrapped: %w", err)
+
+	assertErrorKind(
+		t,
+		err,
+		apperror.ValidationError,
+		apperror.IsValidation,
+	)
+
+	expected := []apperror.Violation{exampleViolation}
+	if got := apperror.Violations(err); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestWithViolations_preserves_the_original_error(t *testing.T) {
+	original := errors.New(message)
+	err := apperror.WithViolations(original, exampleViolation)
+
+	if !errors.Is(err, original) {
+		t.Error("expected the original error to be in the chain")
+	}
+}
+
+func TestWithViolations_preserves_metadata(t *testing.T) {
+	err := apperror.AsValidation(errors.New("pq: secret"))
+	err = apperror.WithReason(err, apperror.Reason{Code: "INVALID"})
+	err = apperror.WithPublicMessage(err, message)
+	err = apperror.WithViolations(err, exampleViolation)
+
+	if got, _ := apperror.ReasonOf(err); got.Code != "INVALID" {
+		t.Errorf(`expected "INVALID", got "%v"`, got.Code)
+	}
+	if got := apperror.PublicMessage(err); got != message {
+		t.Errorf(`expected "%v", got "%v"`, message, got)
+	}
+}
+
+func TestWithViolations_errors_are_comparable(t *testing.T) {
+	err := apperror.WithViolations(errors.New(message), exampleViolation)
+
+	// Hashing would panic if the error were not comparable.
+	seen := map[error]struct{}{err: {}}
+	if _, ok := seen[err]; !ok {
+		t.Error("expected error to be found")
+	}
+}
+
+func TestViolations_returns_nil_without_violations(t *testing.T) {
+	for _, err := range []error{
+		nil,
+		errors.New(message),
+		apperror.Validation(message),
+	} {
+		if got := apperror.Violations(err); got != nil {
+			t.Errorf("expected nil, got %v", got)
+		}
+	}
+}
+
+func TestViolations_cannot_modify_the_error(t *testing.T) {
+	err := apperror.WithViolations(errors.New(message), exampleViolation)
+	apperror.Violations(err)[0].Field = "modified"
+
+	if got := apperror.Violations(err)[0]; got != exampleViolation {
+		t.Errorf("expected %v, got %v", exampleViolation, got)
+	}
+}
+
+var exampleViolation = apperror.Violation{
+	Field:   "name",
+	Reason:  "REQUIRED",
+	Message: "name is required",
+}
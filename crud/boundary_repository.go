@@ -0,0 +1,100 @@
+package crud
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/ddd"
+	"context"
+	"errors"
+)
+
+// BoundaryRepository decorates a Repository so that the kinds of the errors
+// that it returns are translated according to a boundary policy.
+// See apperror.Boundary.
+//
+// Errors returned by the insert and update functions provided by the caller
+// are not translated, since they do not originate in the repository.
+//
+// Implements Repository.
+type BoundaryRepository[
+	A ddd.AggregateRoot[I, S],
+	I comparable,
+	S ddd.Serialization[A],
+] struct {
+	Repository Repository[A, I, S]
+	Boundary   apperror.Boundary
+}
+
+// Get returns the entity with the specified ID.
+func (r BoundaryRepository[A, I, S]) Get(
+	ctx context.Context,
+	id I,
+) (A, error) {
+	x, err := r.Repository.Get(ctx, id)
+	return x, r.Boundary.Cross(err)
+}
+
+// Insert a new entity into the repository.
+func (r BoundaryRepository[A, I, S]) Insert(
+	ctx context.Context,
+	x A,
+) error {
+	return r.Boundary.Cross(r.Repository.Insert(ctx, x))
+}
+
+// Update an entity already present in the repository.
+func (r BoundaryRepository[A, I, S]) Update(
+	ctx context.Context,
+	id I,
+	update func(x A) error,
+) error {
+	var callerErr error
+	err := r.Repository.Update(ctx, id, func(x A) error {
+		callerErr = update(x)
+		return callerErr
+	})
+
+	return r.cross(err, callerErr)
+}
+
+// Upsert inserts or updates the entity with the specified ID.
+func (r BoundaryRepository[A, I, S]) Upsert(
+	ctx context.Context,
+	id I,
+	insert func() (A, error),
+	update func(x A) error,
+) error {
+	var callerErr error
+	err := r.Repository.Upsert(
+		ctx,
+		id,
+		func() (A, error) {
+			var x A
+			x, callerErr = insert()
+			return x, callerErr
+		},
+		func(x A) error {
+			callerErr = update(x)
+			return callerErr
+		},
+	)
+
+	return r.cross(err, callerErr)
+}
+
+// Delete the entity with the given ID from the repository.
+func (r BoundaryRepository[A, I, S]) Delete(
+	ctx context.Context,
+	id I,
+) error {
+	return r.Boundary.Cross(r.Repository.Delete(ctx, id))
+}
+
+// cross translates err unless it comes from a function provided by the
+// caller.
+func (r BoundaryRepository[A, I, S]) cross(err, callerErr error) error {
+	if callerErr != nil && errors.Is(err, callerErr) {
+		return err
+	}
+
+	return r.Boundary.Cross(err)
+}
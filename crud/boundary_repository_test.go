@@ -0,0 +1,80 @@
+package crud_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/crud"
+	"context"
+	"testing"
+)
+
+var _ EntityRepository = crud.BoundaryRepository[
+	*Entity,
+	int64,
+	EntitySerialization,
+]{}
+
+func NewDownstreamEntityRepository() crud.BoundaryRepository[
+	*Entity,
+	int64,
+	EntitySerialization,
+] {
+	return crud.BoundaryRepository[*Entity, int64, EntitySerialization]{
+		Repository: NewInMemoryEntityRepository(),
+		Boundary: apperror.Boundary{
+			apperror.NotFoundError:   apperror.UnknownError,
+			apperror.ConflictError:   apperror.UnknownError,
+			apperror.ValidationError: apperror.UnknownError,
+		},
+	}
+}
+
+func TestBoundaryRepository_translates_repository_errors(t *testing.T) {
+	r := NewDownstreamEntityRepository()
+	ctx := context.TODO()
+	entity := example()
+
+	if _, err := r.Get(ctx, entity.ID()); !apperror.IsUnknown(err) {
+		t.Error("Get: expected unknown error, got:", err)
+	}
+	if err := r.Delete(ctx, entity.ID()); !apperror.IsUnknown(err) {
+		t.Error("Delete: expected unknown error, got:", err)
+	}
+
+	rename := func(x *Entity) error { return x.Rename("Bob") }
+	if err := r.Update(ctx, entity.ID(), rename); !apperror.IsUnknown(err) {
+		t.Error("Update: expected unknown error, got:", err)
+	}
+
+	if err := r.Insert(ctx, entity); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if err := r.Insert(ctx, entity); !apperror.IsUnknown(err) {
+		t.Error("Insert: expected unknown error, got:", err)
+	}
+}
+
+func TestBoundaryRepository_preserves_caller_errors(t *testing.T) {
+	r := NewDownstreamEntityRepository()
+	ctx := context.TODO()
+	entity := example()
+	givenItExists(t, r, entity)
+
+	invalidRename := func(x *Entity) error { return x.Rename(" ") }
+	err := r.Update(ctx, entity.ID(), invalidRename)
+	if !apperror.IsValidation(err) {
+		t.Error("Update: expected validation error, got:", err)
+	}
+
+	err = r.Upsert(ctx, entity.ID(), nil, invalidRename)
+	if !apperror.IsValidation(err) {
+		t.Error("Upsert: expected validation error, got:", err)
+	}
+
+	invalidInsert := func() (*Entity, error) {
+		return nil, apperror.Validation("invalid entity")
+	}
+	err = r.Upsert(ctx, entity.ID()+1, invalidInsert, invalidRename)
+	if !apperror.IsValidation(err) {
+		t.Error("Upsert: expected validation error, got:", err)
+	}
+}
@@ -0,0 +1,22 @@
+package event
+
+import (
+	"artk.dev/apperror"
+	"context"
+)
+
+// Recover is ObserverMiddleware that recovers panics in observers and
+// returns them as unknown errors, which carry the panic value and the stack
+// trace. See apperror.FromPanic.
+//
+// Place it after any middleware that should see those errors, e.g., logging
+// middleware, so that it wraps the observer more closely.
+func Recover[Event any](next Observer[Event]) Observer[Event] {
+	return func(ctx context.Context, e Event) error {
+		return apperror.Catch(func() error {
+			return next(ctx, e)
+		})
+	}
+}
+
+var _ ObserverMiddleware[any] = Recover[any]
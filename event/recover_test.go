@@ -0,0 +1,34 @@
+package event_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/event"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRecover_converts_panics_into_unknown_errors(t *testing.T) {
+	observer := event.Recover(func(_ context.Context, _ Event) error {
+		panic("test panic")
+	})
+
+	err := observer(context.TODO(), exampleEvent())
+	if !apperror.IsUnknown(err) {
+		t.Error("expected unknown error, got:", err)
+	}
+	if v, ok := apperror.PanicValue(err); !ok || v != "test panic" {
+		t.Error("unexpected panic value:", v)
+	}
+}
+
+func TestRecover_propagates_errors(t *testing.T) {
+	expected := errors.New("test error")
+	observer := event.Recover(func(_ context.Context, _ Event) error {
+		return expected
+	})
+
+	if err := observer(context.TODO(), exampleEvent()); err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
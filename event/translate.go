@@ -0,0 +1,17 @@
+package event
+
+import (
+	"artk.dev/apperror"
+	"context"
+)
+
+// Translate returns ObserverMiddleware that translates the kinds of the
+// errors returned by observers according to a boundary policy.
+// See apperror.Boundary.
+func Translate[Event any](b apperror.Boundary) ObserverMiddleware[Event] {
+	return func(next Observer[Event]) Observer[Event] {
+		return func(ctx context.Context, e Event) error {
+			return b.Cross(next(ctx, e))
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package event_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/event"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTranslate_translates_error_kinds(t *testing.T) {
+	original := apperror.NotFound("test error")
+	middleware := event.Translate[Event](apperror.Boundary{
+		apperror.NotFoundError: apperror.UnknownError,
+	})
+	observer := middleware(func(_ context.Context, _ Event) error {
+		return original
+	})
+
+	err := observer(context.TODO(), exampleEvent())
+	if !apperror.IsUnknown(err) {
+		t.Error("expected unknown error, got:", err)
+	}
+	if !errors.Is(err, original) {
+		t.Error("the original error is not in the chain")
+	}
+}
+
+func TestTranslate_preserves_success(t *testing.T) {
+	middleware := event.Translate[Event](apperror.Boundary{
+		apperror.OK: apperror.UnknownError,
+	})
+	observer := middleware(func(_ context.Context, _ Event) error {
+		return nil
+	})
+
+	if err := observer(context.TODO(), exampleEvent()); err != nil {
+		t.Error("unexpected error:", err)
+	}
+}
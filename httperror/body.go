@@ -0,0 +1,288 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// DecodeJSONBody decodes the application/json body of a request into a value
+// of type T. Every failure is an apperror, which can be encoded directly
+// with the encoders in this package:
+//
+//   - Bodies with another content type are validation errors with the
+//     UNSUPPORTED_MEDIA_TYPE reason, encoded as 415 Unsupported Media Type.
+//   - Bodies larger than the limit are validation errors with the
+//     BODY_TOO_LARGE reason, encoded as 413 Content Too Large.
+//     See WithMaxBodySize.
+//   - Malformed JSON, values of the wrong type and unknown fields are
+//     validation errors with a violation. For values of the wrong type, the
+//     field of the violation is a JSON pointer to the offending value,
+//     e.g., "/customer/name". Versions of Go before 1.24 omit array
+//     indices and map keys from it. For unknown fields, it is empty, since
+//     their location is not known.
+//   - Empty bodies and bodies with several JSON values are validation
+//     errors.
+//
+// The ResponseWriter w is only used to close the connection when the body
+// is too large. See http.MaxBytesReader.
+func DecodeJSONBody[T any](
+	w http.ResponseWriter,
+	r *http.Request,
+	optionsFn ...func(options *bodyOptions),
+) (T, error) {
+	assume.NotZero(w)
+	assume.NotZero(r)
+
+	options := bodyOptions{maxBodySize: defaultMaxBodySize}
+	for _, fn := range optionsFn {
+		fn(&options)
+	}
+
+	var value, zero T
+	if err := checkJSONContentType(r.Header); err != nil {
+		return zero, err
+	}
+
+	body := http.MaxBytesReader(w, r.Body, options.maxBodySize)
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&value); err != nil {
+		return zero, bodyError(err)
+	}
+
+	// The body must contain a single JSON value.
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return zero, trailingDataError(err)
+	}
+
+	return value, nil
+}
+
+// WithMaxBodySize limits the size of the body read by DecodeJSONBody, in
+// bytes. It must be positive. The default is 1 MiB.
+func WithMaxBodySize(n int64) func(options *bodyOptions) {
+	assume.Truef(n > 0, "max body size must be positive (was %v)", n)
+
+	return func(options *bodyOptions) {
+		options.maxBodySize = n
+	}
+}
+
+type bodyOptions struct {
+	maxBodySize int64
+}
+
+func checkJSONContentType(header http.Header) error {
+	value := header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err == nil && (mediaType == jsonContentType ||
+		strings.HasSuffix(mediaType, jsonSuffix)) {
+		return nil
+	}
+
+	err = apperror.Validationf(
+		"unsupported content type %q: expected %v",
+		value,
+		jsonContentType,
+	)
+	err = apperror.WithReason(err, apperror.Reason{
+		Code: reasonUnsupportedMediaType,
+	})
+	return withStatus(err, http.StatusUnsupportedMediaType)
+}
+
+// bodyError maps the errors that happen while decoding a body to apperrors.
+func bodyError(err error) error {
+	var (
+		maxBytesErr *http.MaxBytesError
+		syntaxErr   *json.SyntaxError
+		typeErr     *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &maxBytesErr):
+		err = apperror.Validationf(
+			"request body is larger than %v bytes",
+			maxBytesErr.Limit,
+		)
+		err = apperror.WithReason(err, apperror.Reason{
+			Code: reasonBodyTooLarge,
+		})
+		return withStatus(err, http.StatusRequestEntityTooLarge)
+	case errors.Is(err, io.EOF):
+		return apperror.Validation("request body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return violation(
+			"",
+			reasonInvalidJSON,
+			"unexpected end of JSON",
+		)
+	case errors.As(err, &syntaxErr):
+		return violation("", reasonInvalidJSON, fmt.Sprintf(
+			"invalid JSON at offset %v",
+			syntaxErr.Offset,
+		))
+	case errors.As(err, &typeErr):
+		// Go types are an implementation detail, so only describe the
+		// expected JSON type.
+		return violation(
+			jsonPointer(strings.Split(typeErr.Field, ".")),
+			reasonInvalidType,
+			fmt.Sprintf(
+				"expected %v, got %v",
+				jsonType(typeErr.Type),
+				typeErr.Value,
+			),
+		)
+	}
+
+	// Unfortunately, encoding/json does not export this error.
+	const unknownFieldPrefix = "json: unknown field "
+	if name, ok := strings.CutPrefix(err.Error(), unknownFieldPrefix); ok {
+		// Only the innermost name is known, so the field would not be a
+		// valid pointer.
+		name = strings.Trim(name, `"`)
+		return violation(
+			"",
+			reasonUnknownField,
+			fmt.Sprintf("unknown field %q", name),
+		)
+	}
+
+	return apperror.Unknownf("cannot read request body: %w", err)
+}
+
+// trailingDataError maps the result of reading past the decoded value.
+func trailingDataError(err error) error {
+	var syntaxErr *json.SyntaxError
+	if err != nil && !errors.As(err, &syntaxErr) {
+		return bodyError(err)
+	}
+
+	return apperror.Validation(
+		"request body must contain a single JSON value",
+	)
+}
+
+func violation(field, reason, msg string) error {
+	return apperror.WithViolations(
+		apperror.Validation("invalid request body"),
+		apperror.Violation{
+			Field:   field,
+			Reason:  reason,
+			Message: msg,
+		},
+	)
+}
+
+// jsonType returns the JSON type that encoding/json decodes into t.
+func jsonType(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch pointer := reflect.PointerTo(t); {
+	case pointer.Implements(jsonUnmarshalerType):
+		return "value"
+	case pointer.Implements(textUnmarshalerType):
+		return "string"
+	}
+
+	if name, ok := jsonTypes[t.Kind()]; ok {
+		return name
+	}
+
+	return "value"
+}
+
+// jsonPointer builds an RFC 6901 JSON pointer from its reference tokens.
+func jsonPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		if token == "" {
+			continue
+		}
+
+		b.WriteByte('/')
+		token = strings.ReplaceAll(token, "~", "~0")
+		token = strings.ReplaceAll(token, "/", "~1")
+		b.WriteString(token)
+	}
+
+	return b.String()
+}
+
+// statusError overrides the status code of an error. It is used when the
+// kind alone cannot represent the status code that HTTP clients expect.
+type statusError struct {
+	error
+	status int
+}
+
+func (e *statusError) Unwrap() error {
+	return e.error
+}
+
+func withStatus(err error, status int) error {
+	return &statusError{error: err, status: status}
+}
+
+// statusOverride returns the status code set by withStatus, if any.
+func statusOverride(err error) (int, bool) {
+	var target *statusError
+	if !errors.As(err, &target) {
+		return 0, false
+	}
+
+	return target.status, true
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+var jsonTypes = map[reflect.Kind]string{
+	reflect.Bool:    "bool",
+	reflect.Int:     "number",
+	reflect.Int8:    "number",
+	reflect.Int16:   "number",
+	reflect.Int32:   "number",
+	reflect.Int64:   "number",
+	reflect.Uint:    "number",
+	reflect.Uint8:   "number",
+	reflect.Uint16:  "number",
+	reflect.Uint32:  "number",
+	reflect.Uint64:  "number",
+	reflect.Uintptr: "number",
+	reflect.Float32: "number",
+	reflect.Float64: "number",
+	reflect.String:  "string",
+	reflect.Array:   "array",
+	reflect.Slice:   "array",
+	reflect.Map:     "object",
+	reflect.Struct:  "object",
+}
+
+const (
+	defaultMaxBodySize = 1 << 20
+	jsonSuffix         = "+json"
+
+	reasonBodyTooLarge         = "BODY_TOO_LARGE"
+	reasonInvalidJSON          = "INVALID_JSON"
+	reasonInvalidType          = "INVALID_TYPE"
+	reasonUnknownField         = "UNKNOWN_FIELD"
+	reasonUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+)
@@ -0,0 +1,268 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type order struct {
+	Customer customer `json:"customer"`
+	Quantity int      `json:"quantity"`
+}
+
+type customer struct {
+	Name string `json:"name"`
+}
+
+func TestDecodeJSONBody_decodes_valid_bodies(t *testing.T) {
+	for _, contentType := range []string{
+		"application/json",
+		"application/json; charset=utf-8",
+		"application/merge-patch+json",
+	} {
+		t.Run(contentType, func(t *testing.T) {
+			const body = `{"customer":{"name":"Ann"},"quantity":2}`
+			r := jsonRequest(body)
+			r.Header.Set("Content-Type", contentType)
+
+			got, err := httperror.DecodeJSONBody[order](
+				httptest.NewRecorder(),
+				r,
+			)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			expected := order{
+				Customer: customer{Name: "Ann"},
+				Quantity: 2,
+			}
+			if got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONBody_reports_violations(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		body   string
+		field  string
+		reason string
+	}{
+		{
+			name:   "Malformed JSON",
+			body:   `{"quantity":}`,
+			field:  "",
+			reason: "INVALID_JSON",
+		},
+		{
+			name:   "Truncated JSON",
+			body:   `{"quantity":2`,
+			field:  "",
+			reason: "INVALID_JSON",
+		},
+		{
+			name:   "Wrong type",
+			body:   `{"quantity":"two"}`,
+			field:  "/quantity",
+			reason: "INVALID_TYPE",
+		},
+		{
+			name:   "Wrong nested type",
+			body:   `{"customer":{"name":2}}`,
+			field:  "/customer/name",
+			reason: "INVALID_TYPE",
+		},
+		{
+			name:   "Unknown field",
+			body:   `{"price":2}`,
+			field:  "",
+			reason: "UNKNOWN_FIELD",
+		},
+		{
+			name:   "Unknown nested field",
+			body:   `{"customer":{"nme":"Ann"}}`,
+			field:  "",
+			reason: "UNKNOWN_FIELD",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := httperror.DecodeJSONBody[order](
+				httptest.NewRecorder(),
+				jsonRequest(tc.body),
+			)
+
+			if !apperror.IsValidation(err) {
+				t.Fatal("expected validation error, got", err)
+			}
+			violations := apperror.Violations(err)
+			if len(violations) != 1 {
+				t.Fatal("unexpected violations:", violations)
+			}
+			got := violations[0]
+			if got.Field != tc.field {
+				t.Errorf(
+					`expected "%v", got "%v"`,
+					tc.field,
+					got.Field,
+				)
+			}
+			if got.Reason != tc.reason {
+				t.Errorf(
+					`expected "%v", got "%v"`,
+					tc.reason,
+					got.Reason,
+				)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONBody_describes_JSON_types(t *testing.T) {
+	for body, expected := range map[string]string{
+		`{"quantity":"two"}`:         "expected number, got string",
+		`{"customer":2}`:             "expected object, got number",
+		`{"customer":{"name":true}}`: "expected string, got bool",
+	} {
+		t.Run(body, func(t *testing.T) {
+			_, err := httperror.DecodeJSONBody[order](
+				httptest.NewRecorder(),
+				jsonRequest(body),
+			)
+
+			violations := apperror.Violations(err)
+			if len(violations) != 1 {
+				t.Fatal("unexpected violations:", violations)
+			}
+			if got := violations[0].Message; got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONBody_rejects_invalid_bodies(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		body string
+	}{
+		{name: "Empty body", body: ""},
+		{name: "Several values", body: `{"quantity":1}{"quantity":2}`},
+		{name: "Trailing data", body: `{"quantity":1}]`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			_, err := httperror.DecodeJSONBody[order](
+				w,
+				jsonRequest(tc.body),
+			)
+
+			if !apperror.IsValidation(err) {
+				t.Fatal("expected validation error, got", err)
+			}
+			httperror.EncodeToText(w, err)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf(
+					"expected %v, got %v",
+					http.StatusBadRequest,
+					w.Code,
+				)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONBody_rejects_other_content_types(t *testing.T) {
+	for _, contentType := range []string{"", "text/plain", "invalid;"} {
+		t.Run(contentType, func(t *testing.T) {
+			r := jsonRequest(`{"quantity":2}`)
+			r.Header.Set("Content-Type", contentType)
+
+			w := httptest.NewRecorder()
+			_, err := httperror.DecodeJSONBody[order](w, r)
+			expectBodyError(t, err, "UNSUPPORTED_MEDIA_TYPE")
+
+			httperror.EncodeToJSON(w, err)
+			if w.Code != http.StatusUnsupportedMediaType {
+				t.Errorf(
+					"expected %v, got %v",
+					http.StatusUnsupportedMediaType,
+					w.Code,
+				)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONBody_rejects_oversized_bodies(t *testing.T) {
+	body := `{"customer":{"name":"` + strings.Repeat("a", 64) + `"}}`
+
+	w := httptest.NewRecorder()
+	_, err := httperror.DecodeJSONBody[order](
+		w,
+		jsonRequest(body),
+		httperror.WithMaxBodySize(32),
+	)
+	expectBodyError(t, err, "BODY_TOO_LARGE")
+
+	httperror.EncodeToText(w, err)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf(
+			"expected %v, got %v",
+			http.StatusRequestEntityTooLarge,
+			w.Code,
+		)
+	}
+}
+
+func TestDecodeJSONBody_status_override_requires_validation(t *testing.T) {
+	w := httptest.NewRecorder()
+	_, err := httperror.DecodeJSONBody[order](
+		w,
+		jsonRequest(`{"quantity":2}`),
+		httperror.WithMaxBodySize(4),
+	)
+	err = apperror.AsUnknown(err)
+
+	expected := http.StatusInternalServerError
+	if got := httperror.EncodeHeader(w.Header(), err); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestWithMaxBodySize_must_be_positive(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic")
+		}
+	}()
+
+	httperror.WithMaxBodySize(0)
+}
+
+func expectBodyError(t *testing.T, err error, reason string) {
+	t.Helper()
+
+	if !apperror.IsValidation(err) {
+		t.Fatal("expected validation error, got", err)
+	}
+	got, ok := apperror.ReasonOf(err)
+	if !ok {
+		t.Fatal("missing reason")
+	}
+	if got.Code != reason {
+		t.Errorf(`expected "%v", got "%v"`, reason, got.Code)
+	}
+}
+
+func jsonRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	return r
+}
@@ -0,0 +1,170 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"net/http"
+	"strings"
+)
+
+// encodeChallenge sets the WWW-Authenticate header if the error is
+// unauthorized and carries an apperror.Challenge.
+func encodeChallenge(header http.Header, err error) {
+	if !apperror.IsUnauthorized(err) {
+		return
+	}
+
+	challenge, ok := apperror.ChallengeOf(err)
+	if !ok || !isToken(challenge.Scheme) {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(challenge.Scheme)
+	separator := " "
+	for _, param := range []struct {
+		name  string
+		value string
+	}{
+		{name: "realm", value: challenge.Realm},
+		{name: "error", value: challenge.Error},
+		{name: "error_description", value: challenge.ErrorDescription},
+	} {
+		if param.value == "" {
+			continue
+		}
+
+		b.WriteString(separator)
+		b.WriteString(param.name)
+		b.WriteByte('=')
+		b.WriteString(quote(param.value))
+		separator = ", "
+	}
+
+	header.Set(challengeHeader, b.String())
+}
+
+// decodeChallenge attaches the first challenge of the WWW-Authenticate
+// header to unauthorized errors, if any. Unknown parameters are ignored.
+func decodeChallenge(header http.Header, err error) error {
+	if !apperror.IsUnauthorized(err) {
+		return err
+	}
+
+	challenge, ok := parseChallenge(header.Get(challengeHeader))
+	if !ok {
+		return err
+	}
+
+	return apperror.WithChallenge(err, challenge)
+}
+
+// parseChallenge parses the first challenge of a WWW-Authenticate header,
+// e.g., `Bearer realm="example", error="invalid_token"`.
+func parseChallenge(value string) (apperror.Challenge, bool) {
+	value = strings.TrimSpace(value)
+	end := strings.IndexAny(value, " \t,")
+	if end < 0 {
+		end = len(value)
+	}
+	scheme, rest := value[:end], value[end:]
+	if !isToken(scheme) {
+		return apperror.Challenge{}, false
+	}
+
+	challenge := apperror.Challenge{Scheme: scheme}
+	for {
+		name, paramValue, remaining, ok := nextParam(rest)
+		if !ok {
+			// Either the end of the header or the next challenge.
+			return challenge, true
+		}
+
+		rest = remaining
+		switch strings.ToLower(name) {
+		case "realm":
+			challenge.Realm = paramValue
+		case "error":
+			challenge.Error = paramValue
+		case "error_description":
+			challenge.ErrorDescription = paramValue
+		}
+	}
+}
+
+// nextParam parses the next auth-param, i.e., a name followed by a token or
+// a quoted string, and returns the remaining input.
+func nextParam(s string) (name, value, rest string, ok bool) {
+	s = strings.TrimLeft(s, " \t,")
+	name, rest, found := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if !found || !isToken(name) {
+		return "", "", "", false
+	}
+
+	rest = strings.TrimLeft(rest, " \t")
+	if !strings.HasPrefix(rest, `"`) {
+		end := strings.IndexAny(rest, " \t,")
+		if end < 0 {
+			end = len(rest)
+		}
+		return name, rest[:end], rest[end:], true
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(rest); i++ {
+		switch c := rest[i]; c {
+		case '"':
+			return name, b.String(), rest[i+1:], true
+		case '\\':
+			if i+1 < len(rest) {
+				i++
+				b.WriteByte(rest[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	// Unterminated quoted string.
+	return "", "", "", false
+}
+
+// quote returns s as a quoted string, as defined by RFC 9110.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := range len(s) {
+		c := s[i]
+		if c == '"' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// isToken returns true if s is a non-empty token, as defined by RFC 9110.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := range len(s) {
+		c := s[i]
+		isAlphanumeric := c >= 'a' && c <= 'z' ||
+			c >= 'A' && c <= 'Z' ||
+			c >= '0' && c <= '9'
+		isSymbol := strings.ContainsRune(tokenSymbols, rune(c))
+		if !isAlphanumeric && !isSymbol {
+			return false
+		}
+	}
+
+	return true
+}
+
+const (
+	challengeHeader = "WWW-Authenticate"
+	tokenSymbols    = "!#$%&'*+-.^_`|~"
+)
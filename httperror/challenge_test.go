@@ -0,0 +1,218 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEncodeToText_encodes_challenge_header(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		challenge apperror.Challenge
+		expected  string
+	}{
+		{
+			name:      "Scheme only",
+			challenge: apperror.Challenge{Scheme: "Bearer"},
+			expected:  "Bearer",
+		},
+		{
+			name:      "All parameters",
+			challenge: testChallenge,
+			expected: `Bearer realm="example", ` +
+				`error="invalid_token", ` +
+				`error_description="The \"access\" ` +
+				`token expired"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := apperror.Unauthorized(errorMessage)
+			err = apperror.WithChallenge(err, tc.challenge)
+
+			w := httptest.NewRecorder()
+			httperror.EncodeToText(w, err)
+
+			got := w.Header().Get("WWW-Authenticate")
+			if got != tc.expected {
+				t.Errorf(
+					"expected %q, got %q",
+					tc.expected,
+					got,
+				)
+			}
+		})
+	}
+}
+
+func TestEncodeToText_omits_challenge_header(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+	}{
+		{
+			name: "Without challenge",
+			err:  apperror.Unauthorized(errorMessage),
+		},
+		{
+			name: "Not unauthorized",
+			err: apperror.WithChallenge(
+				apperror.Forbidden(errorMessage),
+				testChallenge,
+			),
+		},
+		{
+			name: "Invalid scheme",
+			err: apperror.WithChallenge(
+				apperror.Unauthorized(errorMessage),
+				apperror.Challenge{Scheme: "Bearer realm"},
+			),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			httperror.EncodeToText(w, tc.err)
+
+			got := w.Header().Get("WWW-Authenticate")
+			if got != "" {
+				t.Errorf("unexpected value %q", got)
+			}
+		})
+	}
+}
+
+func TestDecodeFromText_challenge_encoding_is_reversible(t *testing.T) {
+	for _, challenge := range []apperror.Challenge{
+		testChallenge,
+		{Scheme: "Basic", Realm: `back\slash`},
+		{Scheme: "Bearer"},
+	} {
+		t.Run(challenge.Scheme, func(t *testing.T) {
+			err := apperror.Unauthorized(errorMessage)
+			err = apperror.WithChallenge(err, challenge)
+			decodedErr := encodeAndDecode(err)
+
+			got, ok := apperror.ChallengeOf(decodedErr)
+			if !ok {
+				t.Fatal("missing challenge")
+			}
+			if got != challenge {
+				t.Errorf("expected %v, got %v", challenge, got)
+			}
+			kind := apperror.KindOf(decodedErr)
+			if kind != apperror.UnauthorizedError {
+				t.Error("unexpected kind:", kind)
+			}
+		})
+	}
+}
+
+func TestDecodeFromText_parses_third_party_challenges(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		value    string
+		expected apperror.Challenge
+	}{
+		{
+			name:  "Token values",
+			value: `Bearer realm=example,error=invalid_token`,
+			expected: apperror.Challenge{
+				Scheme: "Bearer",
+				Realm:  "example",
+				Error:  "invalid_token",
+			},
+		},
+		{
+			name:  "Case-insensitive names",
+			value: `Bearer Realm="example", ERROR="invalid_token"`,
+			expected: apperror.Challenge{
+				Scheme: "Bearer",
+				Realm:  "example",
+				Error:  "invalid_token",
+			},
+		},
+		{
+			name:  "Unknown parameters",
+			value: `Bearer scope="orders", realm="example"`,
+			expected: apperror.Challenge{
+				Scheme: "Bearer",
+				Realm:  "example",
+			},
+		},
+		{
+			name:  "Several challenges",
+			value: `Basic realm="basic", Bearer realm="bearer"`,
+			expected: apperror.Challenge{
+				Scheme: "Basic",
+				Realm:  "basic",
+			},
+		},
+		{
+			name:  "Unterminated quoted string",
+			value: `Bearer realm="example`,
+			expected: apperror.Challenge{
+				Scheme: "Bearer",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			response := challengeResponse(tc.value)
+			err := httperror.DecodeFromText(response)
+
+			got, ok := apperror.ChallengeOf(err)
+			if !ok {
+				t.Fatal("missing challenge")
+			}
+			if got != tc.expected {
+				t.Errorf(
+					"expected %v, got %v",
+					tc.expected,
+					got,
+				)
+			}
+		})
+	}
+}
+
+func TestDecodeFromText_ignores_invalid_challenges(t *testing.T) {
+	for _, value := range []string{"", " ", `="example"`} {
+		t.Run(value, func(t *testing.T) {
+			response := challengeResponse(value)
+			err := httperror.DecodeFromText(response)
+
+			if _, ok := apperror.ChallengeOf(err); ok {
+				t.Error("unexpected challenge")
+			}
+		})
+	}
+}
+
+func TestDecodeFromJSON_decodes_challenge(t *testing.T) {
+	err := apperror.Unauthorized(errorMessage)
+	err = apperror.WithChallenge(err, testChallenge)
+	decodedErr := encodeAndDecodeJSON(err)
+
+	got, ok := apperror.ChallengeOf(decodedErr)
+	if !ok {
+		t.Fatal("missing challenge")
+	}
+	if got != testChallenge {
+		t.Errorf("expected %v, got %v", testChallenge, got)
+	}
+}
+
+func challengeResponse(value string) *http.Response {
+	w := httptest.NewRecorder()
+	w.Header().Set("WWW-Authenticate", value)
+	http.Error(w, errorMessage, http.StatusUnauthorized)
+	return w.Result()
+}
+
+var testChallenge = apperror.Challenge{
+	Scheme:           "Bearer",
+	Realm:            "example",
+	Error:            "invalid_token",
+	ErrorDescription: `The "access" token expired`,
+}
@@ -0,0 +1,236 @@
+package httperror
+
+import (
+	"artk.dev/assume"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Encoder writes an error into an HTTP response.
+// No further writes to the ResponseWriter w should happen after it returns.
+type Encoder func(w http.ResponseWriter, r *http.Request, err error)
+
+// Encode writes an error into an HTTP response, in the format that best
+// matches the Accept header of the request.
+//
+// The registered encoders are considered, see RegisterEncoder. By default,
+// these are:
+//
+//   - text/plain: EncodeToText.
+//   - application/json: EncodeToJSON.
+//   - application/problem+json: EncodeToProblem.
+//   - text/html: EncodeToHTML.
+//
+// It falls back to text/plain if the request has no Accept header, or if no
+// registered encoder is acceptable.
+// No further writes to the ResponseWriter w should happen after this function.
+func Encode(w http.ResponseWriter, r *http.Request, err error) {
+	DefaultMapper.Encode(w, r, err)
+}
+
+// Encode is like the Encode function, but the default encoders use the
+// Mapper to map kinds and status codes.
+func (m Mapper) Encode(w http.ResponseWriter, r *http.Request, err error) {
+	assume.NotZero(w)
+	assume.NotZero(r)
+
+	w.Header().Add("Vary", "Accept")
+	encoder := negotiate(r.Header.Values("Accept"))
+	encoder(m)(w, r, err)
+}
+
+// RegisterEncoder adds an Encoder for a media type, such as "text/csv",
+// to the registry consulted by Encode. It replaces the existing Encoder for
+// the same media type, if any, including the default ones.
+//
+// When an Accept header allows several media types with the same
+// preference, e.g., "application/*", encoders are chosen in registration
+// order, after the default ones.
+//
+// It is safe to call RegisterEncoder concurrently with Encode, although it
+// is typically called during initialization.
+func RegisterEncoder(mediaType string, encoder Encoder) {
+	if encoder == nil {
+		panic("encoder cannot be nil")
+	}
+
+	parsed, params, err := mime.ParseMediaType(mediaType)
+	if err != nil || len(params) > 0 || strings.Contains(parsed, "*") {
+		panic("invalid media type: " + mediaType)
+	}
+
+	encodersMutex.Lock()
+	defer encodersMutex.Unlock()
+
+	// Copy on write, so that readers never need to lock.
+	updated := slices.Clone(*encoders.Load())
+	entry := registeredEncoder{
+		mediaType: parsed,
+		encoder: func(Mapper) Encoder {
+			return encoder
+		},
+	}
+	i := slices.IndexFunc(updated, entry.hasSameMediaType)
+	if i >= 0 {
+		updated[i] = entry
+	} else {
+		updated = append(updated, entry)
+	}
+	encoders.Store(&updated)
+}
+
+type registeredEncoder struct {
+	mediaType string
+
+	// The default encoders depend on the Mapper.
+	encoder func(m Mapper) Encoder
+}
+
+func (e registeredEncoder) hasSameMediaType(other registeredEncoder) bool {
+	return e.mediaType == other.mediaType
+}
+
+// negotiate returns the registered Encoder that best matches the values of
+// the Accept header.
+func negotiate(accept []string) func(m Mapper) Encoder {
+	registered := *encoders.Load()
+	ranges := parseAccept(accept)
+	for _, r := range ranges {
+		if r.quality <= 0 {
+			continue
+		}
+
+		for _, e := range registered {
+			if !r.matches(e.mediaType) {
+				continue
+			}
+
+			if !isRejected(ranges, e.mediaType) {
+				return e.encoder
+			}
+		}
+	}
+
+	// Fall back to the text/plain Encoder, which may have been replaced.
+	i := slices.IndexFunc(registered, func(e registeredEncoder) bool {
+		return e.mediaType == textContentType
+	})
+	return registered[i].encoder
+}
+
+// mediaRange is an element of the Accept header, e.g., "text/*;q=0.5".
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+func (r mediaRange) matches(mediaType string) bool {
+	if r.mediaType == "*/*" {
+		return true
+	}
+
+	prefix, isWildcard := strings.CutSuffix(r.mediaType, "*")
+	if !isWildcard {
+		return r.mediaType == mediaType
+	}
+
+	return strings.HasPrefix(mediaType, prefix)
+}
+
+// specificity is higher for more specific media ranges.
+func (r mediaRange) specificity() int {
+	return -strings.Count(r.mediaType, "*")
+}
+
+// parseAccept returns the media ranges in the values of the Accept header,
+// sorted by preference. Malformed media ranges are ignored.
+func parseAccept(values []string) []mediaRange {
+	var ranges []mediaRange
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			mediaType, params, err := mime.ParseMediaType(part)
+			if err != nil {
+				continue
+			}
+
+			quality := 1.0
+			if q, ok := params["q"]; ok {
+				quality, err = strconv.ParseFloat(q, 64)
+				if err != nil {
+					continue
+				}
+			}
+
+			ranges = append(ranges, mediaRange{
+				mediaType: mediaType,
+				quality:   quality,
+			})
+		}
+	}
+
+	slices.SortStableFunc(ranges, func(a, b mediaRange) int {
+		if a.quality != b.quality {
+			if a.quality > b.quality {
+				return -1
+			}
+			return 1
+		}
+
+		return b.specificity() - a.specificity()
+	})
+
+	return ranges
+}
+
+// isRejected returns true if the media type is explicitly not acceptable,
+// e.g., "application/json;q=0".
+func isRejected(ranges []mediaRange, mediaType string) bool {
+	return slices.ContainsFunc(ranges, func(r mediaRange) bool {
+		return r.quality <= 0 && r.mediaType == mediaType
+	})
+}
+
+// Adapters of the encoders that do not need the request.
+
+func (m Mapper) textEncoder() Encoder {
+	return func(w http.ResponseWriter, _ *http.Request, err error) {
+		m.EncodeToText(w, err)
+	}
+}
+
+func (m Mapper) jsonEncoder() Encoder {
+	return func(w http.ResponseWriter, _ *http.Request, err error) {
+		m.EncodeToJSON(w, err)
+	}
+}
+
+func (m Mapper) problemEncoder() Encoder {
+	return m.EncodeToProblem
+}
+
+func (m Mapper) htmlEncoder() Encoder {
+	return func(w http.ResponseWriter, _ *http.Request, err error) {
+		m.EncodeToHTML(w, err)
+	}
+}
+
+var (
+	encodersMutex sync.Mutex
+	encoders      atomic.Pointer[[]registeredEncoder]
+)
+
+func init() {
+	encoders.Store(&[]registeredEncoder{
+		{mediaType: textContentType, encoder: Mapper.textEncoder},
+		{mediaType: jsonContentType, encoder: Mapper.jsonEncoder},
+		{mediaType: problemContentType, encoder: Mapper.problemEncoder},
+		{mediaType: htmlContentType, encoder: Mapper.htmlEncoder},
+	})
+}
+
+const textContentType = "text/plain"
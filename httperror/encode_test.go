@@ -0,0 +1,185 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleEncode() {
+	r := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+	r.Header.Set("Accept", "application/problem+json, */*;q=0.1")
+
+	w := httptest.NewRecorder()
+	httperror.Encode(w, r, apperror.NotFound("order not found"))
+
+	fmt.Println(w.Code)
+	fmt.Println(w.Header().Get("Content-Type"))
+
+	// Output:
+	// 404
+	// application/problem+json
+}
+
+func TestEncode_negotiates_the_content_type(t *testing.T) {
+	for _, tc := range []struct {
+		accept   string
+		expected string
+	}{
+		{"", "text/plain"},
+		{"*/*", "text/plain"},
+		{"application/json;q=0.5, */*", "text/plain"},
+		{"*/*;q=0.5, application/json", "application/json"},
+		{"text/plain", "text/plain"},
+		{"application/json", "application/json"},
+		{"application/problem+json", "application/problem+json"},
+		{"text/html", "text/html"},
+		{"image/png", "text/plain"},
+		{"invalid", "text/plain"},
+		{"application/*", "application/json"},
+		{
+			"application/*, application/json;q=0",
+			"application/problem+json",
+		},
+		{"text/*;q=0.5, application/json", "application/json"},
+		{"application/json;q=0.5, text/html;q=0.8", "text/html"},
+		{
+			"*/*;q=0.1, application/problem+json",
+			"application/problem+json",
+		},
+		{"text/html, */*;q=0.8", "text/html"},
+		{
+			"text/html,application/xhtml+xml,*/*;q=0.8",
+			"text/html",
+		},
+	} {
+		t.Run(tc.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.accept != "" {
+				r.Header.Set("Accept", tc.accept)
+			}
+
+			err := apperror.Conflict(errorMessage)
+			w := httptest.NewRecorder()
+			httperror.Encode(w, r, err)
+
+			expected := tc.expected
+			got := mediaType(t, w.Header().Get("Content-Type"))
+			if got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+			if vary := w.Header().Get("Vary"); vary != "Accept" {
+				t.Errorf(`expected "Accept", got "%v"`, vary)
+			}
+		})
+	}
+}
+
+func TestRegisterEncoder_adds_and_replaces_encoders(t *testing.T) {
+	httperror.RestoreEncodersAfter(t)
+
+	const csv = "text/vnd.httperror-test"
+	httperror.RegisterEncoder(
+		csv,
+		func(w http.ResponseWriter, _ *http.Request, _ error) {
+			w.Header().Set("Content-Type", "text/csv")
+			w.WriteHeader(http.StatusTeapot)
+		},
+	)
+	httperror.RegisterEncoder(
+		csv,
+		func(w http.ResponseWriter, _ *http.Request, _ error) {
+			w.Header().Set("Content-Type", csv)
+			w.WriteHeader(http.StatusTeapot)
+		},
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", csv)
+	w := httptest.NewRecorder()
+	httperror.Encode(w, r, apperror.Conflict(errorMessage))
+
+	if got := w.Header().Get("Content-Type"); got != csv {
+		t.Errorf("expected %v, got %v", csv, got)
+	}
+	if w.Code != http.StatusTeapot {
+		t.Errorf("expected %v, got %v", http.StatusTeapot, w.Code)
+	}
+}
+
+func TestRegisterEncoder_replaces_the_fallback_encoder(t *testing.T) {
+	httperror.RestoreEncodersAfter(t)
+	httperror.RegisterEncoder(
+		"text/plain",
+		func(w http.ResponseWriter, _ *http.Request, _ error) {
+			w.WriteHeader(http.StatusTeapot)
+		},
+	)
+
+	for _, accept := range []string{"", "image/png"} {
+		t.Run(accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if accept != "" {
+				r.Header.Set("Accept", accept)
+			}
+
+			err := apperror.Conflict(errorMessage)
+			w := httptest.NewRecorder()
+			httperror.Encode(w, r, err)
+
+			const expected = http.StatusTeapot
+			if got := w.Code; got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestRegisterEncoder_panics_for_invalid_arguments(t *testing.T) {
+	var encoder httperror.Encoder = func(
+		http.ResponseWriter,
+		*http.Request,
+		error,
+	) {
+	}
+
+	for name, register := range map[string]func(){
+		"nil encoder": func() {
+			httperror.RegisterEncoder("text/csv", nil)
+		},
+		"malformed": func() {
+			httperror.RegisterEncoder("text/", encoder)
+		},
+		"wildcard": func() {
+			httperror.RegisterEncoder("text/*", encoder)
+		},
+		"parameters": func() {
+			httperror.RegisterEncoder("text/csv;q=0.5", encoder)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("missing expected panic")
+				}
+			}()
+
+			register()
+		})
+	}
+}
+
+func mediaType(t *testing.T, contentType string) string {
+	t.Helper()
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return mediaType
+}
@@ -0,0 +1,12 @@
+package httperror
+
+import "testing"
+
+// RestoreEncodersAfter restores the registry of encoders when the test ends,
+// so that tests can replace the default encoders.
+func RestoreEncodersAfter(t testing.TB) {
+	saved := encoders.Load()
+	t.Cleanup(func() {
+		encoders.Store(saved)
+	})
+}
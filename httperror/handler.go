@@ -0,0 +1,112 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"log/slog"
+	"net/http"
+)
+
+// Handler is an http.Handler that can fail. Returned errors are encoded
+// into the response, so that handlers do not need to do it themselves.
+//
+// By default, errors are encoded with Encode, and unknown errors are
+// reported to slog.Default. Use Handler.With to change this behavior.
+//
+// If the handler already started writing the response when it fails, the
+// error cannot be encoded anymore. It is reported instead, regardless of its
+// kind, and the response is left as is.
+type Handler func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP implements http.Handler.
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, &handlerOptions{})
+}
+
+// With returns an http.Handler that runs h with the specified options.
+func (h Handler) With(
+	optionsFn ...func(options *handlerOptions),
+) http.Handler {
+	options := &handlerOptions{}
+	for _, fn := range optionsFn {
+		fn(options)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.serve(w, r, options)
+	})
+}
+
+func (h Handler) serve(
+	w http.ResponseWriter,
+	r *http.Request,
+	options *handlerOptions,
+) {
+	tracked := &trackingResponseWriter{ResponseWriter: w}
+	err := h(tracked, r)
+	if err == nil {
+		return
+	}
+
+	switch {
+	case tracked.wroteHeader:
+		options.log(r, err, "HTTP handler failed after responding")
+	case apperror.IsUnknown(err):
+		options.log(r, err, "HTTP handler failed")
+		options.encode(w, r, err)
+	default:
+		options.encode(w, r, err)
+	}
+}
+
+// WithEncoder sets the Encoder used by a Handler. By default, it is Encode.
+func WithEncoder(encoder Encoder) func(options *handlerOptions) {
+	assume.Truef(encoder != nil, "encoder cannot be nil")
+
+	return func(options *handlerOptions) {
+		options.encoder = encoder
+	}
+}
+
+// WithLogger sets the logger used by a Handler to report errors.
+// By default, it is slog.Default.
+func WithLogger(logger *slog.Logger) func(options *handlerOptions) {
+	assume.NotZero(logger)
+
+	return func(options *handlerOptions) {
+		options.logger = logger
+	}
+}
+
+type handlerOptions struct {
+	encoder Encoder
+	logger  *slog.Logger
+}
+
+func (o *handlerOptions) encode(
+	w http.ResponseWriter,
+	r *http.Request,
+	err error,
+) {
+	if o.encoder == nil {
+		Encode(w, r, err)
+		return
+	}
+
+	o.encoder(w, r, err)
+}
+
+func (o *handlerOptions) log(r *http.Request, err error, msg string) {
+	logger := o.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	logger.ErrorContext(
+		r.Context(),
+		msg,
+		slog.String("method", r.Method),
+		slog.String("path", r.URL.Path),
+		slog.Attr{Key: "error", Value: apperror.LogValue(err)},
+	)
+}
@@ -0,0 +1,250 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExampleHandler() {
+	getOrder := httperror.Handler(func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) error {
+		if r.PathValue("id") != "42" {
+			return apperror.NotFound("order not found")
+		}
+
+		_, err := fmt.Fprintln(w, "order 42")
+		return err
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /orders/{id}", getOrder)
+
+	for _, path := range []string{"/orders/42", "/orders/43"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		mux.ServeHTTP(w, r)
+		fmt.Print(w.Code, " ", w.Body.String())
+	}
+
+	// Output:
+	// 200 order 42
+	// 404 order not found
+}
+
+func TestHandler_encodes_errors(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			h := failingHandler(apperror.New(kind, errorMessage))
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			h.With(withDiscardLogger).ServeHTTP(w, r)
+
+			expected := httperror.EncodeKind(kind)
+			if got := w.Code; got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestHandler_uses_the_configured_encoder(t *testing.T) {
+	h := failingHandler(apperror.Conflict(errorMessage))
+	encoder := func(w http.ResponseWriter, _ *http.Request, err error) {
+		httperror.EncodeToJSON(w, err)
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.With(httperror.WithEncoder(encoder)).ServeHTTP(w, r)
+
+	const expected = "application/json"
+	if got := w.Header().Get("Content-Type"); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestHandler_logs_only_unknown_errors(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		if kind == apperror.OK {
+			// Special case: there is no error.
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			h := failingHandler(apperror.New(kind, errorMessage))
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, nil))
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			h.With(httperror.WithLogger(logger)).ServeHTTP(w, r)
+
+			expected := kind == apperror.UnknownError
+			got := strings.Contains(logs.String(), errorMessage)
+			if got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestHandler_does_not_overwrite_started_responses(t *testing.T) {
+	h := httperror.Handler(func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) error {
+		w.WriteHeader(http.StatusAccepted)
+		return apperror.Conflict(errorMessage)
+	})
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.With(httperror.WithLogger(logger)).ServeHTTP(w, r)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected %v, got %v", http.StatusAccepted, w.Code)
+	}
+	if w.Body.Len() > 0 {
+		t.Error("unexpected body:", w.Body.String())
+	}
+	if !strings.Contains(logs.String(), errorMessage) {
+		t.Error("the error was not logged")
+	}
+}
+
+func TestHandler_detects_implicit_headers(t *testing.T) {
+	h := httperror.Handler(func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) error {
+		_, _ = w.Write([]byte("partial"))
+		return errors.New(errorMessage)
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.With(withDiscardLogger).ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected %v, got %v", http.StatusOK, w.Code)
+	}
+	if got := w.Body.String(); got != "partial" {
+		t.Errorf(`expected "partial", got "%v"`, got)
+	}
+}
+
+func TestHandler_supports_response_controller(t *testing.T) {
+	h := httperror.Handler(func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) error {
+		return http.NewResponseController(w).Flush()
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.ServeHTTP(w, r)
+
+	if !w.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+}
+
+func TestHandler_supports_flushers(t *testing.T) {
+	h := httperror.Handler(func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) error {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("expected an http.Flusher")
+		}
+
+		flusher.Flush()
+		return apperror.Conflict(errorMessage)
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.With(withDiscardLogger).ServeHTTP(w, r)
+
+	if !w.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+	if w.Code != http.StatusOK {
+		t.Errorf("expected %v, got %v", http.StatusOK, w.Code)
+	}
+}
+
+func TestHandler_supports_hijackers(t *testing.T) {
+	h := httperror.Handler(func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) error {
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("expected an http.Hijacker")
+		}
+
+		if _, _, err := hijacker.Hijack(); err != nil {
+			return err
+		}
+
+		return apperror.Conflict(errorMessage)
+	})
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	w := &hijackableRecorder{ResponseRecorder: httptest.NewRecorder()}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	h.With(httperror.WithLogger(logger)).ServeHTTP(w, r)
+
+	if !w.hijacked {
+		t.Error("expected the connection to be hijacked")
+	}
+	if w.Body.Len() > 0 {
+		t.Error("unexpected body:", w.Body.String())
+	}
+	if !strings.Contains(logs.String(), "after responding") {
+		t.Error("the error was not logged:", logs.String())
+	}
+}
+
+func failingHandler(err error) httperror.Handler {
+	return func(http.ResponseWriter, *http.Request) error {
+		return err
+	}
+}
+
+var withDiscardLogger = httperror.WithLogger(
+	slog.New(slog.NewTextHandler(io.Discard, nil)),
+)
+
+// hijackableRecorder is an httptest.ResponseRecorder that can be hijacked.
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (w *hijackableRecorder) Hijack() (
+	net.Conn,
+	*bufio.ReadWriter,
+	error,
+) {
+	w.hijacked = true
+	return nil, nil, nil
+}
@@ -0,0 +1,37 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"net/http"
+)
+
+// EncodeHeader sets the headers that carry the metadata of an error, i.e.,
+// Retry-After, Error-Reason, Error-Domain and WWW-Authenticate, and returns
+// the status code for its kind. It is meant for custom encoders, which only
+// need to write the status code and the body afterward.
+//
+// Errors returned by DecodeJSONBody may keep a more specific status code
+// than their kind, e.g., 413 Content Too Large.
+func EncodeHeader(header http.Header, err error) int {
+	return DefaultMapper.EncodeHeader(header, err)
+}
+
+// EncodeHeader is like the EncodeHeader function, but it uses the Mapper to
+// map kinds and status codes. It also sets the Error-Kind header if the
+// Mapper is configured to do so. See Mapper.WithKindHeader.
+func (m Mapper) EncodeHeader(header http.Header, err error) int {
+	assume.NotNilMap(header)
+
+	kind := apperror.KindOf(err)
+	encodeRetryAfter(header, err)
+	encodeReason(header, err)
+	encodeChallenge(header, err)
+	m.encodeKindHeader(header, kind)
+	status, ok := statusOverride(err)
+	if ok && kind == apperror.ValidationError {
+		return status
+	}
+
+	return m.EncodeKind(kind)
+}
@@ -0,0 +1,45 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEncodeHeader_returns_status_code_of_kind(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, errorMessage)
+			header := make(http.Header)
+
+			expected := httperror.EncodeKind(kind)
+			got := httperror.EncodeHeader(header, err)
+			if got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestEncodeHeader_encodes_metadata(t *testing.T) {
+	err := apperror.TooManyRequests(errorMessage)
+	err = apperror.WithRetryAfter(err, 3*time.Second)
+	err = apperror.WithReason(err, testReason)
+
+	header := make(http.Header)
+	m := httperror.DefaultMapper.WithKindHeader()
+	_ = m.EncodeHeader(header, err)
+
+	for name, expected := range map[string]string{
+		"Retry-After":  "3",
+		"Error-Reason": testReason.Code,
+		"Error-Domain": testReason.Domain,
+		"Error-Kind":   "too_many_requests",
+	} {
+		if got := header.Get(name); got != expected {
+			t.Errorf("%v: expected %q, got %q", name, expected, got)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"html/template"
+	"io"
+	"net/http"
+)
+
+// EncodeToHTML encodes an error into a text/html fragment, which is meant to
+// be embedded into a page, e.g., by htmx. The fragment contains the public
+// message of the error and its violations, if any.
+// See apperror.PublicMessage.
+//
+// The fragment is a div element with class "error" and the ARIA role
+// "alert". Its data-kind attribute contains the text encoding of the kind,
+// and the data-field attribute of each violation contains its field.
+//
+// Like EncodeToText, it also sets the Retry-After, Error-Reason,
+// Error-Domain and WWW-Authenticate headers if applicable, and it redacts
+// unknown errors.
+// No further writes to the ResponseWriter w should happen after this function.
+func EncodeToHTML(w http.ResponseWriter, err error) {
+	DefaultMapper.EncodeToHTML(w, err)
+}
+
+// EncodeToHTML is like the EncodeToHTML function, but it uses the Mapper to map
+// kinds and status codes.
+func (m Mapper) EncodeToHTML(w http.ResponseWriter, err error) {
+	assume.NotZero(w)
+
+	kind := apperror.KindOf(err)
+	status := m.EncodeHeader(w.Header(), err)
+
+	if kind == apperror.OK {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", htmlContentType+"; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+
+	// The status code is already sent, so errors cannot be reported.
+	_ = WriteHTML(
+		w,
+		kind,
+		publicMessage(kind, status, err),
+		apperror.Violations(err),
+	)
+}
+
+// WriteHTML writes the fragment that EncodeToHTML sends for an error with
+// the specified kind, public message and violations. Unlike EncodeToHTML,
+// it writes no headers, so that the fragment can be embedded into other
+// responses. The message and the violations are escaped as text.
+func WriteHTML(
+	w io.Writer,
+	kind apperror.Kind,
+	msg string,
+	violations []apperror.Violation,
+) error {
+	assume.NotZero(w)
+
+	kindName, _ := kind.MarshalText()
+	return htmlTemplate.Execute(w, htmlData{
+		Kind:       string(kindName),
+		Message:    msg,
+		Violations: violations,
+	})
+}
+
+type htmlData struct {
+	Kind       string
+	Message    string
+	Violations []apperror.Violation
+}
+
+var htmlTemplate = template.Must(template.New("error").Parse(
+	`<div class="error" role="alert" data-kind="{{.Kind}}">
+<p>{{.Message}}</p>
+{{- with .Violations}}
+<ul>
+{{- range .}}
+<li data-field="{{.Field}}">{{.Message}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</div>
+`))
+
+const htmlContentType = "text/html"
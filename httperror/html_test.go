@@ -0,0 +1,107 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExampleEncodeToHTML() {
+	err := apperror.WithViolations(
+		apperror.Validation("invalid order"),
+		apperror.Violation{
+			Field:   "quantity",
+			Reason:  "NOT_POSITIVE",
+			Message: "quantity must be positive",
+		},
+	)
+
+	w := httptest.NewRecorder()
+	httperror.EncodeToHTML(w, err)
+
+	fmt.Print(w.Body.String())
+
+	// Output:
+	// <div class="error" role="alert" data-kind="validation">
+	// <p>invalid order</p>
+	// <ul>
+	// <li data-field="quantity">quantity must be positive</li>
+	// </ul>
+	// </div>
+}
+
+func TestEncodeToHTML_encodes_kind_into_status_code(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, errorMessage)
+			w := httptest.NewRecorder()
+			httperror.EncodeToHTML(w, err)
+
+			expected := httperror.EncodeKind(kind)
+			if got := w.Code; got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestEncodeToHTML_content_type_is_HTML(t *testing.T) {
+	w := httptest.NewRecorder()
+	httperror.EncodeToHTML(w, apperror.Conflict(errorMessage))
+
+	const expected = "text/html"
+	got := mediaType(t, w.Header().Get("Content-Type"))
+	if got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestEncodeToHTML_escapes_messages(t *testing.T) {
+	w := httptest.NewRecorder()
+	httperror.EncodeToHTML(w, apperror.Conflict("<script>"))
+
+	if body := w.Body.String(); strings.Contains(body, "<script>") {
+		t.Error("unescaped message:", body)
+	}
+}
+
+func TestWriteHTML_writes_the_fragment_of_EncodeToHTML(t *testing.T) {
+	err := apperror.WithViolations(
+		apperror.Validation(errorMessage),
+		apperror.Violation{Field: "quantity", Message: "<b>"},
+	)
+	w := httptest.NewRecorder()
+	httperror.EncodeToHTML(w, err)
+
+	var b strings.Builder
+	writeErr := httperror.WriteHTML(
+		&b,
+		apperror.ValidationError,
+		errorMessage,
+		apperror.Violations(err),
+	)
+	if writeErr != nil {
+		t.Fatal("unexpected error:", writeErr)
+	}
+
+	if expected := w.Body.String(); b.String() != expected {
+		t.Errorf("expected %v, got %v", expected, b.String())
+	}
+}
+
+func TestEncodeToHTML_redacts_unknown_errors(t *testing.T) {
+	w := httptest.NewRecorder()
+	httperror.EncodeToHTML(w, errors.New("an unknown error"))
+
+	body := w.Body.String()
+	if strings.Contains(body, "an unknown error") {
+		t.Error("leaked message:", body)
+	}
+	if !strings.Contains(body, "Internal Server Error") {
+		t.Error("missing status text:", body)
+	}
+}
@@ -0,0 +1,157 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// jsonError is the representation of an error in JSON.
+type jsonError struct {
+	Kind       apperror.Kind        `json:"kind"`
+	Message    string               `json:"message,omitempty"`
+	Violations []apperror.Violation `json:"violations,omitempty"`
+	Reason     string               `json:"reason,omitempty"`
+	Domain     string               `json:"domain,omitempty"`
+}
+
+// EncodeToJSON encodes an error into an application/json body, which
+// contains its kind, its public message, and its violations and reason, if
+// any. See apperror.PublicMessage.
+//
+// Like EncodeToText, it also sets the Retry-After, Error-Reason,
+// Error-Domain and WWW-Authenticate headers if applicable, and it redacts
+// unknown errors.
+// No further writes to the ResponseWriter w should happen after this function.
+func EncodeToJSON(w http.ResponseWriter, err error) {
+	DefaultMapper.EncodeToJSON(w, err)
+}
+
+// EncodeToJSON is like the EncodeToJSON function, but it uses the Mapper to map
+// kinds and status codes.
+func (m Mapper) EncodeToJSON(w http.ResponseWriter, err error) {
+	newBody := func(kind apperror.Kind, status int) any {
+		reason, _ := apperror.ReasonOf(err)
+		return jsonError{
+			Kind:       kind,
+			Message:    publicMessage(kind, status, err),
+			Violations: apperror.Violations(err),
+			Reason:     reason.Code,
+			Domain:     reason.Domain,
+		}
+	}
+
+	m.encodeJSON(w, err, jsonContentType, newBody)
+}
+
+// DecodeFromJSON decodes an error from an application/json body written by
+// EncodeToJSON.
+//
+// The kind in the body takes precedence over the Error-Kind header and the
+// status code of the response, because several kinds may share the same
+// status code. Kinds that are not recognized, e.g., because they were added
+// in a newer version of apperror, are ignored.
+func DecodeFromJSON(response *http.Response) error {
+	return DefaultMapper.DecodeFromJSON(response)
+}
+
+// DecodeFromJSON is like the DecodeFromJSON function, but it uses the Mapper to
+// map kinds and status codes.
+func (m Mapper) DecodeFromJSON(response *http.Response) error {
+	assume.NotZero(response)
+
+	kind := m.decodeResponseKind(response)
+	if kind == apperror.OK {
+		return nil
+	}
+
+	var decoded struct {
+		jsonError
+
+		// Shadows jsonError.Kind, so that kinds added by newer servers
+		// do not cause the decoding to fail.
+		Kind string `json:"kind"`
+	}
+	if err := readJSON(response, &decoded); err != nil {
+		return err
+	}
+
+	// Unknown or contradictory kinds fall back to the kind of the
+	// Error-Kind header or the status code.
+	if exact, err := apperror.ParseKind(decoded.Kind); err == nil &&
+		exact != apperror.OK {
+		kind = exact
+	}
+
+	return decodeDetails(
+		response.Header,
+		apperror.New(kind, decoded.Message),
+		decoded.Violations,
+		apperror.Reason{Code: decoded.Reason, Domain: decoded.Domain},
+	)
+}
+
+// encodeJSON writes the JSON body returned by newBody, like EncodeToText
+// would write plain text. It does not call newBody for OK.
+func (m Mapper) encodeJSON(
+	w http.ResponseWriter,
+	err error,
+	contentType string,
+	newBody func(kind apperror.Kind, status int) any,
+) {
+	assume.NotZero(w)
+
+	kind := apperror.KindOf(err)
+	status := m.EncodeHeader(w.Header(), err)
+
+	if kind == apperror.OK {
+		w.WriteHeader(status)
+		return
+	}
+
+	body := newBody(kind, status)
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+
+	// The status code is already sent, so errors cannot be reported.
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// readJSON reads the JSON body of an error response into v.
+func readJSON(response *http.Response, v any) error {
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return apperror.Unknownf("cannot parse HTTP error: %w", err)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return apperror.Unknownf("cannot parse HTTP error: %w", err)
+	}
+
+	return nil
+}
+
+// decodeDetails attaches the details that the JSON bodies carry in addition
+// to the kind and the message, as well as those of the headers.
+func decodeDetails(
+	header http.Header,
+	err error,
+	violations []apperror.Violation,
+	reason apperror.Reason,
+) error {
+	if apperror.IsValidation(err) && len(violations) > 0 {
+		err = apperror.WithViolations(err, violations...)
+	}
+	if reason.Code != "" {
+		err = apperror.WithReason(err, reason)
+	}
+
+	err = decodeChallenge(header, err)
+	return decodeRetryAfter(header, err)
+}
+
+const jsonContentType = "application/json"
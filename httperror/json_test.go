@@ -0,0 +1,188 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"artk.dev/broken"
+	"artk.dev/httperror"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func ExampleEncodeToJSON() {
+	err := apperror.WithReason(
+		apperror.Conflict("already shipped"),
+		apperror.Reason{Code: "SHIPPED"},
+	)
+
+	w := httptest.NewRecorder()
+	httperror.EncodeToJSON(w, err)
+
+	fmt.Print(w.Body.String())
+
+	// Output:
+	// {"kind":"conflict","message":"already shipped","reason":"SHIPPED"}
+}
+
+func TestEncodeToJSON_encodes_kind_into_status_code(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, errorMessage)
+			w := httptest.NewRecorder()
+			httperror.EncodeToJSON(w, err)
+
+			expected := httperror.EncodeKind(kind)
+			if got := w.Code; got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestEncodeToJSON_content_type_is_JSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	httperror.EncodeToJSON(w, apperror.Conflict(errorMessage))
+
+	const expected = "application/json"
+	if got := w.Header().Get("Content-Type"); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestEncodeToJSON_redacts_unknown_errors(t *testing.T) {
+	w := httptest.NewRecorder()
+	httperror.EncodeToJSON(w, errors.New("an unknown error"))
+
+	const expected = `{"kind":"unknown",` +
+		`"message":"Internal Server Error"}`
+	if got := strings.TrimSpace(w.Body.String()); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDecodeFromJSON_kind_encoding_is_reversible(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			originalErr := apperror.New(kind, errorMessage)
+			decodedErr := encodeAndDecodeJSON(originalErr)
+			if got := apperror.KindOf(decodedErr); got != kind {
+				t.Errorf("expected %v, got %v", kind, got)
+			}
+		})
+	}
+}
+
+func TestDecodeFromJSON_message_encoding_is_reversible(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		if kind == apperror.UnknownError {
+			// Special case: the message is redacted.
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			originalErr := apperror.New(kind, errorMessage)
+			decodedErr := encodeAndDecodeJSON(originalErr)
+			assertEqualMessage(t, originalErr, decodedErr)
+		})
+	}
+}
+
+func TestDecodeFromJSON_details_encoding_is_reversible(t *testing.T) {
+	err := apperror.WithViolations(
+		apperror.Validation(errorMessage),
+		exampleViolation,
+	)
+	err = apperror.WithReason(err, testReason)
+	decodedErr := encodeAndDecodeJSON(err)
+
+	expected := []apperror.Violation{exampleViolation}
+	got := apperror.Violations(decodedErr)
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	reason, ok := apperror.ReasonOf(decodedErr)
+	if !ok || reason != testReason {
+		t.Errorf("expected %v, got %v", testReason, reason)
+	}
+}
+
+func TestDecodeFromJSON_return_unknown_error_on_failure(t *testing.T) {
+	for name, body := range map[string]io.ReadCloser{
+		"broken reader": io.NopCloser(broken.Reader{}),
+		"invalid JSON":  io.NopCloser(strings.NewReader("not JSON")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			response := &http.Response{
+				Status:     "409 Conflict",
+				StatusCode: http.StatusConflict,
+				Header: http.Header{
+					"Content-Type": []string{
+						"application/json",
+					},
+				},
+				Body: body,
+			}
+
+			defer func() {
+				assume.Success(body.Close())
+			}()
+
+			err := httperror.DecodeFromJSON(response)
+			expected := apperror.UnknownError
+			if got := apperror.KindOf(err); got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestDecodeFromJSON_ignores_unknown_kinds(t *testing.T) {
+	for _, body := range []string{
+		`{"kind":"payment_required","message":"` +
+			errorMessage + `"}`,
+		`{"kind":"ok","message":"` + errorMessage + `"}`,
+	} {
+		t.Run(body, func(t *testing.T) {
+			response := &http.Response{
+				Status:     "409 Conflict",
+				StatusCode: http.StatusConflict,
+				Header: http.Header{
+					"Content-Type": []string{
+						"application/json",
+					},
+				},
+				Body: io.NopCloser(strings.NewReader(body)),
+			}
+
+			err := httperror.DecodeFromJSON(response)
+			expected := apperror.ConflictError
+			if got := apperror.KindOf(err); got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+
+			const msg = errorMessage
+			if got := apperror.PublicMessage(err); got != msg {
+				t.Errorf("expected %v, got %v", msg, got)
+			}
+		})
+	}
+}
+
+func encodeAndDecodeJSON(err error) error {
+	w := httptest.NewRecorder()
+	httperror.EncodeToJSON(w, err)
+
+	response := w.Result()
+	defer func() {
+		assume.Success(response.Body.Close())
+	}()
+
+	return httperror.DecodeFromJSON(response)
+}
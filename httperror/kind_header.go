@@ -0,0 +1,59 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"net/http"
+)
+
+// WithKindHeader returns a copy of the Mapper whose encoders also send the
+// exact kind of errors in the Error-Kind header, using the text encoding of
+// apperror.Kind.
+//
+// Decoders always prefer the Error-Kind header over the status code when it
+// is present, so that kinds are preserved exactly between services that use
+// this package. Responses without it, e.g., from third parties or from load
+// balancers, are decoded from the status code as usual.
+func (m Mapper) WithKindHeader() Mapper {
+	m.kindHeader = true
+	return m
+}
+
+// encodeKindHeader sets the Error-Kind header if the Mapper is configured to
+// do so.
+func (m Mapper) encodeKindHeader(header http.Header, kind apperror.Kind) {
+	if !m.kindHeader || kind == apperror.OK {
+		return
+	}
+
+	text, err := kind.MarshalText()
+	if err != nil {
+		return
+	}
+
+	header.Set(kindHeader, string(text))
+}
+
+// decodeResponseKind decodes the kind of a response, preferring the
+// Error-Kind header over the status code.
+func (m Mapper) decodeResponseKind(response *http.Response) apperror.Kind {
+	kind := m.DecodeKind(response.StatusCode)
+	if kind == apperror.OK {
+		return kind
+	}
+
+	value := response.Header.Get(kindHeader)
+	if value == "" {
+		return kind
+	}
+
+	exact, err := apperror.ParseKind(value)
+	if err != nil || exact == apperror.OK {
+		// Unknown or contradictory values are not worth failing the
+		// decoding for.
+		return kind
+	}
+
+	return exact
+}
+
+const kindHeader = "Error-Kind"
@@ -0,0 +1,137 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"artk.dev/httperror"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleMapper_WithKindHeader() {
+	m := httperror.DefaultMapper.WithKindHeader()
+
+	w := httptest.NewRecorder()
+	m.EncodeToText(w, apperror.Gone("order deleted"))
+
+	fmt.Println(w.Code)
+	fmt.Println(w.Header().Get("Error-Kind"))
+
+	// Output:
+	// 410
+	// gone
+}
+
+func TestMapper_WithKindHeader_preserves_kinds_exactly(t *testing.T) {
+	// Every error is sent as a 500, which is as lossy as it gets.
+	encoding := httperror.DefaultMapper.EncodingTable()
+	for kind := range encoding {
+		if kind != apperror.OK {
+			encoding[kind] = http.StatusInternalServerError
+		}
+	}
+	m, err := httperror.NewMapper(encoding, nil)
+	assume.Success(err)
+	m = m.WithKindHeader()
+
+	for name, c := range map[string]struct {
+		encode httperror.Encoder
+		decode func(response *http.Response) error
+	}{
+		"text":    {ignoreRequest(m.EncodeToText), m.DecodeFromText},
+		"problem": {m.EncodeToProblem, m.DecodeFromProblem},
+		"HTML":    {ignoreRequest(m.EncodeToHTML), m.DecodeFromText},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, kind := range apperror.KindValues() {
+				err := encodeAndDecodeWith(
+					c.encode,
+					c.decode,
+					apperror.New(kind, errorMessage),
+				)
+				assertKind(t, err, kind)
+			}
+		})
+	}
+}
+
+func TestMapper_kind_header_is_optional(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		w := httptest.NewRecorder()
+		httperror.EncodeToText(w, apperror.New(kind, errorMessage))
+
+		if got := w.Header().Get("Error-Kind"); got != "" {
+			t.Errorf(`%v: unexpected header "%v"`, kind, got)
+		}
+	}
+}
+
+func TestDecodeFromText_prefers_the_kind_header(t *testing.T) {
+	for header, expected := range map[string]apperror.Kind{
+		"":        apperror.UnavailableError,
+		"timeout": apperror.TimeoutError,
+		"invalid": apperror.UnavailableError,
+		"ok":      apperror.UnavailableError,
+	} {
+		t.Run(header, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if header != "" {
+				w.Header().Set("Error-Kind", header)
+			}
+			status := http.StatusServiceUnavailable
+			http.Error(w, errorMessage, status)
+
+			response := w.Result()
+			defer func() {
+				assume.Success(response.Body.Close())
+			}()
+
+			err := httperror.DecodeFromText(response)
+			if got := apperror.KindOf(err); got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestDecodeFromText_ignores_the_kind_header_on_success(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set("Error-Kind", "timeout")
+	w.WriteHeader(http.StatusOK)
+
+	response := w.Result()
+	defer func() {
+		assume.Success(response.Body.Close())
+	}()
+
+	if err := httperror.DecodeFromText(response); err != nil {
+		t.Error("unexpected error:", err)
+	}
+}
+
+func assertKind(t *testing.T, err error, expected apperror.Kind) {
+	t.Helper()
+
+	if got := apperror.KindOf(err); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func encodeAndDecodeWith(
+	encode httperror.Encoder,
+	decode func(response *http.Response) error,
+	err error,
+) error {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	encode(w, r, err)
+
+	response := w.Result()
+	defer func() {
+		assume.Success(response.Body.Close())
+	}()
+
+	return decode(response)
+}
@@ -0,0 +1,216 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"maps"
+	"net/http"
+)
+
+// Mapper maps kinds to HTTP status codes and back. The methods of a Mapper
+// are variants of the functions in this package that use its mapping
+// instead of the default one.
+//
+// The zero value uses the same mapping as DefaultMapper.
+type Mapper struct {
+	encoding   map[apperror.Kind]int
+	decoding   map[int]apperror.Kind
+	kindHeader bool
+}
+
+// DefaultMapper is used by EncodeKind, DecodeKind, and all the functions that
+// depend on them.
+var DefaultMapper = Mapper{
+	encoding: defaultEncoding,
+	decoding: defaultDecoding,
+}
+
+// NewMapper creates a Mapper from an encoding table, which maps every kind
+// to a status code, and a decoding table, which maps status codes back to
+// kinds. Use the tables of DefaultMapper as a starting point.
+//
+// The tables are validated for round-trip consistency:
+//
+//   - The encoding table must contain every value of apperror.KindValues.
+//   - OK must be encoded as a status code below 400, and every other kind
+//     as a status code between 400 and 599.
+//   - The decoding table may only contain status codes between 400 and 599,
+//     and they cannot be decoded as OK or an invalid kind.
+//   - Decoding the status code of a kind must result in the same kind or,
+//     if several kinds share the status code, in one of them.
+//
+// Status codes that are not in the decoding table are decoded like
+// DecodeKind does: as apperror.ValidationError if they are in the 400
+// range, and as apperror.UnknownError if they are in the 500 range.
+func NewMapper(
+	encoding map[apperror.Kind]int,
+	decoding map[int]apperror.Kind,
+) (Mapper, error) {
+	m := Mapper{
+		encoding: make(map[apperror.Kind]int, len(encoding)),
+		decoding: make(map[int]apperror.Kind, len(decoding)),
+	}
+	maps.Copy(m.encoding, encoding)
+	maps.Copy(m.decoding, decoding)
+
+	if err := m.validate(); err != nil {
+		return Mapper{}, err
+	}
+
+	return m, nil
+}
+
+// EncodingTable returns a copy of the table used to encode kinds.
+func (m Mapper) EncodingTable() map[apperror.Kind]int {
+	return maps.Clone(m.encodingTable())
+}
+
+// DecodingTable returns a copy of the table used to decode status codes.
+func (m Mapper) DecodingTable() map[int]apperror.Kind {
+	return maps.Clone(m.decodingTable())
+}
+
+// EncodeKind maps an apperror.Kind to an HTTP status code.
+// Invalid kinds are encoded like apperror.UnknownError.
+func (m Mapper) EncodeKind(kind apperror.Kind) int {
+	status, ok := m.encodingTable()[kind]
+	if !ok {
+		return m.encodingTable()[apperror.UnknownError]
+	}
+
+	return status
+}
+
+// DecodeKind maps an HTTP status code to an apperror.Kind.
+func (m Mapper) DecodeKind(status int) apperror.Kind {
+	// While it might be advisable to handle success status codes before
+	// calling this function, we provide a reasonably safe default.
+	if status < http.StatusBadRequest {
+		return apperror.OK
+	}
+
+	if kind, ok := m.decodingTable()[status]; ok {
+		return kind
+	}
+
+	// Errors in the 400 range are client errors.
+	// Validation is the closest kind.
+	if status < http.StatusInternalServerError {
+		return apperror.ValidationError
+	}
+
+	// Errors in the 500 range are server errors.
+	// Unknown is the closest kind.
+	return apperror.UnknownError
+}
+
+func (m Mapper) encodingTable() map[apperror.Kind]int {
+	if m.encoding == nil {
+		return defaultEncoding
+	}
+
+	return m.encoding
+}
+
+func (m Mapper) decodingTable() map[int]apperror.Kind {
+	if m.decoding == nil {
+		return defaultDecoding
+	}
+
+	return m.decoding
+}
+
+func (m Mapper) validate() error {
+	for status, kind := range m.decoding {
+		if !isErrorStatus(status) {
+			return apperror.Validationf(
+				"cannot decode status code %v: not an error",
+				status,
+			)
+		}
+
+		_, err := kind.MarshalText()
+		if err != nil || kind == apperror.OK {
+			return apperror.Validationf(
+				"cannot decode status code %v as %v",
+				status,
+				kind,
+			)
+		}
+	}
+
+	for _, kind := range apperror.KindValues() {
+		if err := m.validateKind(kind); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m Mapper) validateKind(kind apperror.Kind) error {
+	status, ok := m.encoding[kind]
+	switch {
+	case !ok:
+		return apperror.Validationf("missing status code for %v", kind)
+	case (kind == apperror.OK) == isErrorStatus(status):
+		return apperror.Validationf(
+			"cannot encode %v as status code %v",
+			kind,
+			status,
+		)
+	}
+
+	decoded := m.DecodeKind(status)
+	if m.encoding[decoded] != status {
+		return apperror.Validationf(
+			"%v is encoded as status code %v, but decoded as %v",
+			kind,
+			status,
+			decoded,
+		)
+	}
+
+	return nil
+}
+
+func isErrorStatus(status int) bool {
+	return status >= http.StatusBadRequest && status <= maxErrorStatus
+}
+
+const maxErrorStatus = 599
+
+var defaultEncoding = map[apperror.Kind]int{
+	apperror.OK:                      http.StatusOK,
+	apperror.UnknownError:            http.StatusInternalServerError,
+	apperror.ValidationError:         http.StatusBadRequest,
+	apperror.UnauthorizedError:       http.StatusUnauthorized,
+	apperror.ForbiddenError:          http.StatusForbidden,
+	apperror.NotFoundError:           http.StatusNotFound,
+	apperror.ConflictError:           http.StatusConflict,
+	apperror.PreconditionFailedError: http.StatusPreconditionFailed,
+	apperror.TooManyRequestsError:    http.StatusTooManyRequests,
+	apperror.TimeoutError:            http.StatusGatewayTimeout,
+	apperror.UnimplementedError:      http.StatusNotImplemented,
+	apperror.UnavailableError:        http.StatusServiceUnavailable,
+	apperror.CanceledError:           statusClientClosedRequest,
+	apperror.GoneError:               http.StatusGone,
+}
+
+var defaultDecoding = map[int]apperror.Kind{
+	http.StatusBadRequest:          apperror.ValidationError,
+	http.StatusUnauthorized:        apperror.UnauthorizedError,
+	http.StatusForbidden:           apperror.ForbiddenError,
+	http.StatusNotFound:            apperror.NotFoundError,
+	http.StatusConflict:            apperror.ConflictError,
+	http.StatusPreconditionFailed:  apperror.PreconditionFailedError,
+	http.StatusTooManyRequests:     apperror.TooManyRequestsError,
+	http.StatusGone:                apperror.GoneError,
+	statusClientClosedRequest:      apperror.CanceledError,
+	http.StatusInternalServerError: apperror.UnknownError,
+	http.StatusNotImplemented:      apperror.UnimplementedError,
+	// Detect infrastructure (e.g., load balancer) errors,
+	// in addition to application errors.
+	http.StatusBadGateway:         apperror.TimeoutError,
+	http.StatusServiceUnavailable: apperror.UnavailableError,
+	http.StatusGatewayTimeout:     apperror.TimeoutError,
+}
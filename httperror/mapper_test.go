@@ -0,0 +1,206 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"artk.dev/httperror"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ExampleNewMapper() {
+	encoding := httperror.DefaultMapper.EncodingTable()
+	encoding[apperror.TimeoutError] = http.StatusServiceUnavailable
+
+	decoding := httperror.DefaultMapper.DecodingTable()
+	delete(decoding, http.StatusGatewayTimeout)
+
+	m, err := httperror.NewMapper(encoding, decoding)
+	if err != nil {
+		fmt.Println("Unexpected error:", err)
+		return
+	}
+
+	w := httptest.NewRecorder()
+	m.EncodeToText(w, apperror.Timeout("timed out"))
+	fmt.Println(w.Code)
+
+	// Output: 503
+}
+
+func TestMapper_zero_value_is_the_default(t *testing.T) {
+	var m httperror.Mapper
+	for _, kind := range apperror.KindValues() {
+		expected := httperror.EncodeKind(kind)
+		if got := m.EncodeKind(kind); got != expected {
+			t.Errorf("%v: expected %v, got %v", kind, expected, got)
+		}
+	}
+
+	for status := range 600 {
+		expected := httperror.DecodeKind(status)
+		if got := m.DecodeKind(status); got != expected {
+			t.Errorf(
+				"%d: expected %v, got %v",
+				status,
+				expected,
+				got,
+			)
+		}
+	}
+}
+
+func TestMapper_default_tables_are_valid(t *testing.T) {
+	_, err := httperror.NewMapper(
+		httperror.DefaultMapper.EncodingTable(),
+		httperror.DefaultMapper.DecodingTable(),
+	)
+	if err != nil {
+		t.Error("unexpected error:", err)
+	}
+}
+
+func TestMapper_tables_cannot_be_modified(t *testing.T) {
+	encoding := httperror.DefaultMapper.EncodingTable()
+	encoding[apperror.NotFoundError] = http.StatusTeapot
+	decoding := httperror.DefaultMapper.DecodingTable()
+	decoding[http.StatusNotFound] = apperror.ConflictError
+
+	kind := apperror.NotFoundError
+	if got := httperror.EncodeKind(kind); got != http.StatusNotFound {
+		t.Errorf("expected %v, got %v", http.StatusNotFound, got)
+	}
+	if got := httperror.DecodeKind(http.StatusNotFound); got != kind {
+		t.Errorf("expected %v, got %v", kind, got)
+	}
+}
+
+func TestNewMapper_rejects_inconsistent_encoding(t *testing.T) {
+	for name, modify := range map[string]func(e map[apperror.Kind]int){
+		"missing kind": func(e map[apperror.Kind]int) {
+			delete(e, apperror.GoneError)
+		},
+		"OK is an error": func(e map[apperror.Kind]int) {
+			e[apperror.OK] = http.StatusTeapot
+		},
+		"error is OK": func(e map[apperror.Kind]int) {
+			e[apperror.GoneError] = http.StatusNoContent
+		},
+		"not decodable": func(e map[apperror.Kind]int) {
+			e[apperror.GoneError] = http.StatusTeapot
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			encoding := httperror.DefaultMapper.EncodingTable()
+			modify(encoding)
+
+			_, err := httperror.NewMapper(
+				encoding,
+				httperror.DefaultMapper.DecodingTable(),
+			)
+			if !apperror.IsValidation(err) {
+				t.Error("expected validation error, got:", err)
+			}
+		})
+	}
+}
+
+func TestNewMapper_rejects_inconsistent_decoding(t *testing.T) {
+	for name, modify := range map[string]func(d map[int]apperror.Kind){
+		"success": func(d map[int]apperror.Kind) {
+			d[http.StatusNoContent] = apperror.GoneError
+		},
+		"OK": func(d map[int]apperror.Kind) {
+			d[http.StatusTeapot] = apperror.OK
+		},
+		"invalid kind": func(d map[int]apperror.Kind) {
+			d[http.StatusTeapot] = -1
+		},
+		"not reversible": func(d map[int]apperror.Kind) {
+			d[http.StatusGone] = apperror.NotFoundError
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			decoding := httperror.DefaultMapper.DecodingTable()
+			modify(decoding)
+
+			_, err := httperror.NewMapper(
+				httperror.DefaultMapper.EncodingTable(),
+				decoding,
+			)
+			if !apperror.IsValidation(err) {
+				t.Error("expected validation error, got:", err)
+			}
+		})
+	}
+}
+
+func TestMapper_variants_use_the_mapping(t *testing.T) {
+	encoding := httperror.DefaultMapper.EncodingTable()
+	encoding[apperror.ConflictError] = http.StatusUnprocessableEntity
+	decoding := httperror.DefaultMapper.DecodingTable()
+	decoding[http.StatusUnprocessableEntity] = apperror.ConflictError
+	m, err := httperror.NewMapper(encoding, decoding)
+	assume.Success(err)
+
+	type codec struct {
+		encode httperror.Encoder
+		decode func(response *http.Response) error
+	}
+
+	for name, c := range map[string]codec{
+		"text":       {ignoreRequest(m.EncodeToText), m.DecodeFromText},
+		"JSON":       {ignoreRequest(m.EncodeToJSON), m.DecodeFromJSON},
+		"problem":    {m.EncodeToProblem, m.DecodeFromProblem},
+		"HTML":       {ignoreRequest(m.EncodeToHTML), m.DecodeFromText},
+		"negotiated": {m.Encode, m.DecodeFromText},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			c.encode(w, r, apperror.Conflict(errorMessage))
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("unexpected status code %v", w.Code)
+			}
+
+			response := w.Result()
+			defer func() {
+				assume.Success(response.Body.Close())
+			}()
+
+			err := c.decode(response)
+			if !apperror.IsConflict(err) {
+				t.Error("expected conflict error, got:", err)
+			}
+		})
+	}
+}
+
+func TestTransport_uses_the_mapper(t *testing.T) {
+	decoding := httperror.DefaultMapper.DecodingTable()
+	decoding[http.StatusTeapot] = apperror.ConflictError
+	encoding := httperror.DefaultMapper.EncodingTable()
+	encoding[apperror.ConflictError] = http.StatusTeapot
+	m, err := httperror.NewMapper(encoding, decoding)
+	assume.Success(err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &httperror.Transport{Mapper: m}}
+	response, err := client.Get(server.URL)
+	if err == nil {
+		assume.Success(response.Body.Close())
+	}
+	if !apperror.IsConflict(err) {
+		t.Error("expected conflict error, got:", err)
+	}
+}
@@ -0,0 +1,130 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"net/http"
+)
+
+// Problem is the representation of an error as a problem details object,
+// as defined by RFC 9457.
+//
+// The extension members violations, reason and domain carry the details of
+// apperror.Violations and apperror.ReasonOf, respectively.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type.
+	// It is always "about:blank" when encoding, which means that the
+	// problem has no semantics beyond the status code.
+	Type string `json:"type,omitempty"`
+
+	// Title is a short summary of the problem type.
+	// For "about:blank", it is the text of the status code.
+	Title string `json:"title,omitempty"`
+
+	// Status is the HTTP status code.
+	Status int `json:"status,omitempty"`
+
+	// Detail is the public message of the error.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is a URI reference that identifies the specific occurrence
+	// of the problem. It is the path of the request when encoding.
+	Instance string `json:"instance,omitempty"`
+
+	// Violations describes the invalid fields of validation errors.
+	Violations []apperror.Violation `json:"violations,omitempty"`
+
+	// Reason is the code of the apperror.Reason of the error, if any.
+	Reason string `json:"reason,omitempty"`
+
+	// Domain is the domain of the apperror.Reason of the error, if any.
+	Domain string `json:"domain,omitempty"`
+}
+
+// EncodeToProblem encodes an error into an application/problem+json body.
+// Only the public message of the error is written. See apperror.PublicMessage.
+//
+// Like EncodeToText, it also sets the Retry-After, Error-Reason,
+// Error-Domain and WWW-Authenticate headers if applicable.
+// No further writes to the ResponseWriter w should happen after this function.
+func EncodeToProblem(w http.ResponseWriter, r *http.Request, err error) {
+	DefaultMapper.EncodeToProblem(w, r, err)
+}
+
+// EncodeToProblem is like the EncodeToProblem function, but it uses the Mapper
+// to map kinds and status codes.
+func (m Mapper) EncodeToProblem(
+	w http.ResponseWriter,
+	r *http.Request,
+	err error,
+) {
+	assume.NotZero(r)
+
+	newBody := func(_ apperror.Kind, status int) any {
+		reason, _ := apperror.ReasonOf(err)
+		return Problem{
+			Type:       aboutBlank,
+			Title:      StatusText(status),
+			Status:     status,
+			Detail:     apperror.PublicMessage(err),
+			Instance:   r.URL.Path,
+			Violations: apperror.Violations(err),
+			Reason:     reason.Code,
+			Domain:     reason.Domain,
+		}
+	}
+
+	m.encodeJSON(w, err, problemContentType, newBody)
+}
+
+// DecodeFromProblem decodes an error from an application/problem+json body.
+//
+// The kind is decoded from the Error-Kind header or the status code of the
+// response, like DecodeFromText does. The message is the detail of the
+// problem or, if missing, its title.
+func DecodeFromProblem(response *http.Response) error {
+	return DefaultMapper.DecodeFromProblem(response)
+}
+
+// DecodeFromProblem is like the DecodeFromProblem function, but it uses the
+// Mapper to map kinds and status codes.
+func (m Mapper) DecodeFromProblem(response *http.Response) error {
+	assume.NotZero(response)
+
+	kind := m.decodeResponseKind(response)
+	if kind == apperror.OK {
+		return nil
+	}
+
+	var problem Problem
+	if err := readJSON(response, &problem); err != nil {
+		return err
+	}
+
+	msg := problem.Detail
+	if msg == "" {
+		msg = problem.Title
+	}
+
+	return decodeDetails(
+		response.Header,
+		apperror.New(kind, msg),
+		problem.Violations,
+		apperror.Reason{Code: problem.Reason, Domain: problem.Domain},
+	)
+}
+
+// StatusText is like http.StatusText, but it also supports the non-standard
+// status codes used by this package, such as 499 for apperror.CanceledError.
+func StatusText(status int) string {
+	if status == statusClientClosedRequest {
+		return "Client Closed Request"
+	}
+
+	return http.StatusText(status)
+}
+
+const (
+	aboutBlank         = "about:blank"
+	problemContentType = "application/problem+json"
+)
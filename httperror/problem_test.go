@@ -0,0 +1,305 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"artk.dev/broken"
+	"artk.dev/httperror"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func ExampleEncodeToProblem() {
+	err := apperror.WithViolations(
+		apperror.Validation("invalid order"),
+		apperror.Violation{
+			Field:   "quantity",
+			Reason:  "NOT_POSITIVE",
+			Message: "quantity must be positive",
+		},
+	)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	httperror.EncodeToProblem(w, r, err)
+
+	var problem httperror.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		fmt.Println("Unexpected error:", err)
+		return
+	}
+
+	fmt.Println("Content-Type:", w.Header().Get("Content-Type"))
+	fmt.Println("Type:", problem.Type)
+	fmt.Println("Title:", problem.Title)
+	fmt.Println("Status:", problem.Status)
+	fmt.Println("Detail:", problem.Detail)
+	fmt.Println("Instance:", problem.Instance)
+	fmt.Println("Violations:", problem.Violations)
+
+	// Output:
+	// Content-Type: application/problem+json
+	// Type: about:blank
+	// Title: Bad Request
+	// Status: 400
+	// Detail: invalid order
+	// Instance: /orders
+	// Violations: [{quantity NOT_POSITIVE quantity must be positive}]
+}
+
+func TestEncodeToProblem_encodes_kind_into_status_code(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, errorMessage)
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			httperror.EncodeToProblem(w, r, err)
+
+			expected := httperror.EncodeKind(kind)
+			if got := w.Code; got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestEncodeToProblem_encodes_problem_members(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		if kind == apperror.OK || kind == apperror.UnknownError {
+			// Special cases.
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, errorMessage)
+			problem := encodeToProblem(t, err)
+
+			status := httperror.EncodeKind(kind)
+			expected := httperror.Problem{
+				Type:     "about:blank",
+				Title:    problem.Title,
+				Status:   status,
+				Detail:   errorMessage,
+				Instance: "/orders/42",
+			}
+			if problem.Title == "" {
+				t.Error("missing title")
+			}
+			if fmt.Sprint(problem) != fmt.Sprint(expected) {
+				t.Errorf(
+					"expected %+v, got %+v",
+					expected,
+					problem,
+				)
+			}
+		})
+	}
+}
+
+func TestEncodeToProblem_writes_nothing_if_kind_is_OK(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	httperror.EncodeToProblem(w, r, nil)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected %v, got %v", http.StatusOK, w.Code)
+	}
+	if got := w.Body.String(); got != "" {
+		t.Errorf(`unexpected body "%v"`, got)
+	}
+}
+
+func TestEncodeToProblem_redacts_unknown_errors(t *testing.T) {
+	problem := encodeToProblem(t, errors.New("an unknown error"))
+
+	if problem.Detail != "" {
+		t.Errorf(`unexpected detail "%v"`, problem.Detail)
+	}
+	if expected := "Internal Server Error"; problem.Title != expected {
+		t.Errorf(`expected "%v", got "%v"`, expected, problem.Title)
+	}
+}
+
+func TestEncodeToProblem_does_not_leak_wrap_context(t *testing.T) {
+	err := apperror.NotFound(errorMessage)
+	err = fmt.Errorf("SELECT * FROM orders WHERE tenant = 42: %w", err)
+	problem := encodeToProblem(t, err)
+
+	if problem.Detail != errorMessage {
+		t.Errorf(
+			`expected "%v", got "%v"`,
+			errorMessage,
+			problem.Detail,
+		)
+	}
+}
+
+func TestEncodeToProblem_encodes_reason(t *testing.T) {
+	err := apperror.WithReason(apperror.Conflict(errorMessage), testReason)
+	problem := encodeToProblem(t, err)
+
+	got := apperror.Reason{Code: problem.Reason, Domain: problem.Domain}
+	if got != testReason {
+		t.Errorf("expected %v, got %v", testReason, got)
+	}
+}
+
+func TestEncodeToProblem_panics_for_nil_arguments(t *testing.T) {
+	err := errors.New(errorMessage)
+	for name, encode := range map[string]func(){
+		"writer": func() {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			httperror.EncodeToProblem(nil, r, err)
+		},
+		"request": func() {
+			w := httptest.NewRecorder()
+			httperror.EncodeToProblem(w, nil, err)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("missing expected panic")
+				}
+			}()
+
+			encode()
+		})
+	}
+}
+
+func TestDecodeFromProblem_kind_encoding_is_reversible(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			originalErr := apperror.New(kind, errorMessage)
+			decodedErr := encodeAndDecodeProblem(originalErr)
+			if got := apperror.KindOf(decodedErr); got != kind {
+				t.Errorf("expected %v, got %v", kind, got)
+			}
+		})
+	}
+}
+
+func TestDecodeFromProblem_message_encoding_is_reversible(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		if kind == apperror.UnknownError {
+			// Special case: the message is redacted.
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			originalErr := apperror.New(kind, errorMessage)
+			decodedErr := encodeAndDecodeProblem(originalErr)
+			assertEqualMessage(t, originalErr, decodedErr)
+		})
+	}
+}
+
+func TestDecodeFromProblem_uses_title_without_detail(t *testing.T) {
+	decodedErr := encodeAndDecodeProblem(errors.New("an unknown error"))
+
+	const expected = "Internal Server Error"
+	if got := decodedErr.Error(); got != expected {
+		t.Errorf(`expected "%v", got "%v"`, expected, got)
+	}
+}
+
+func TestDecodeFromProblem_details_encoding_is_reversible(t *testing.T) {
+	err := apperror.WithViolations(
+		apperror.Validation(errorMessage),
+		exampleViolation,
+	)
+	err = apperror.WithReason(err, testReason)
+	decodedErr := encodeAndDecodeProblem(err)
+
+	expected := []apperror.Violation{exampleViolation}
+	got := apperror.Violations(decodedErr)
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	reason, ok := apperror.ReasonOf(decodedErr)
+	if !ok || reason != testReason {
+		t.Errorf("expected %v, got %v", testReason, reason)
+	}
+}
+
+func TestDecodeFromProblem_return_unknown_error_on_failure(t *testing.T) {
+	for name, body := range map[string]io.ReadCloser{
+		"broken reader": io.NopCloser(broken.Reader{}),
+		"invalid JSON":  io.NopCloser(strings.NewReader("not JSON")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			response := &http.Response{
+				Status:     "409 Conflict",
+				StatusCode: http.StatusConflict,
+				Header: http.Header{
+					"Content-Type": []string{
+						"application/problem+json",
+					},
+				},
+				Body: body,
+			}
+
+			defer func() {
+				assume.Success(body.Close())
+			}()
+
+			err := httperror.DecodeFromProblem(response)
+			expected := apperror.UnknownError
+			if got := apperror.KindOf(err); got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func encodeToProblem(t *testing.T, err error) httperror.Problem {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(
+		http.MethodGet,
+		"/orders/42?token=secret",
+		nil,
+	)
+	httperror.EncodeToProblem(w, r, err)
+
+	const expectedContentType = "application/problem+json"
+	if got := w.Header().Get("Content-Type"); got != expectedContentType {
+		t.Errorf("expected %v, got %v", expectedContentType, got)
+	}
+
+	var problem httperror.Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	return problem
+}
+
+func encodeAndDecodeProblem(err error) error {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	httperror.EncodeToProblem(w, r, err)
+
+	response := w.Result()
+	defer func() {
+		assume.Success(response.Body.Close())
+	}()
+
+	return httperror.DecodeFromProblem(response)
+}
+
+var exampleViolation = apperror.Violation{
+	Field:   "name",
+	Reason:  "REQUIRED",
+	Message: "name is required",
+}
@@ -0,0 +1,39 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"net/http"
+)
+
+// encodeReason sets the Error-Reason and Error-Domain headers if the error
+// carries an apperror.Reason.
+func encodeReason(header http.Header, err error) {
+	reason, ok := apperror.ReasonOf(err)
+	if !ok {
+		return
+	}
+
+	header.Set(reasonHeader, reason.Code)
+	if reason.Domain != "" {
+		header.Set(domainHeader, reason.Domain)
+	}
+}
+
+// decodeReason attaches the reason from the Error-Reason and Error-Domain
+// headers, if any.
+func decodeReason(header http.Header, err error) error {
+	code := header.Get(reasonHeader)
+	if code == "" {
+		return err
+	}
+
+	return apperror.WithReason(err, apperror.Reason{
+		Code:   code,
+		Domain: header.Get(domainHeader),
+	})
+}
+
+const (
+	reasonHeader = "Error-Reason"
+	domainHeader = "Error-Domain"
+)
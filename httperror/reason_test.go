@@ -0,0 +1,70 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEncodeToText_encodes_reason_headers(t *testing.T) {
+	err := apperror.WithReason(apperror.Conflict(errorMessage), testReason)
+
+	w := httptest.NewRecorder()
+	httperror.EncodeToText(w, err)
+
+	for header, expected := range map[string]string{
+		"Error-Reason": testReason.Code,
+		"Error-Domain": testReason.Domain,
+	} {
+		if got := w.Header().Get(header); got != expected {
+			t.Errorf(
+				`%v: expected "%v", got "%v"`,
+				header,
+				expected,
+				got,
+			)
+		}
+	}
+}
+
+func TestEncodeToText_omits_reason_headers_without_reason(t *testing.T) {
+	w := httptest.NewRecorder()
+	httperror.EncodeToText(w, apperror.Conflict(errorMessage))
+
+	for _, header := range []string{"Error-Reason", "Error-Domain"} {
+		if got := w.Header().Get(header); got != "" {
+			t.Errorf(`%v: unexpected value "%v"`, header, got)
+		}
+	}
+}
+
+func TestDecodeFromText_reason_encoding_is_reversible(t *testing.T) {
+	for _, reason := range []apperror.Reason{
+		testReason,
+		{Code: testReason.Code},
+	} {
+		t.Run(reason.Code, func(t *testing.T) {
+			err := apperror.Conflict(errorMessage)
+			err = apperror.WithReason(err, reason)
+			decodedErr := encodeAndDecode(err)
+
+			got, ok := apperror.ReasonOf(decodedErr)
+			if !ok {
+				t.Fatal("missing reason")
+			}
+			if got != reason {
+				t.Errorf("expected %v, got %v", reason, got)
+			}
+			kind := apperror.KindOf(decodedErr)
+			if kind != apperror.ConflictError {
+				t.Error("unexpected kind:", kind)
+			}
+		})
+	}
+}
+
+var testReason = apperror.Reason{
+	Code:   "ORDER_ALREADY_SHIPPED",
+	Domain: "orders.example.com",
+}
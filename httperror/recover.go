@@ -0,0 +1,111 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// Recover returns middleware that recovers panics in the next handler and
+// encodes them with EncodeToText as unknown errors. See apperror.FromPanic.
+//
+// The optional report function is called with every recovered panic, e.g.,
+// to log it. The error carries the panic value and the stack trace.
+//
+// If the next handler already started writing the response when it panics,
+// the error cannot be encoded anymore. The panic is only reported, and the
+// response is left as is.
+//
+// Panics with http.ErrAbortHandler are propagated, since they are the
+// standard way to abort a response.
+func Recover(
+	report func(r *http.Request, err error),
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(
+			w http.ResponseWriter,
+			r *http.Request,
+		) {
+			tracked := &trackingResponseWriter{ResponseWriter: w}
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+
+				if err, ok := v.(error); ok &&
+					errors.Is(err, http.ErrAbortHandler) {
+					panic(v)
+				}
+
+				err := apperror.FromPanic(v)
+				if report != nil {
+					report(r, err)
+				}
+
+				if !tracked.wroteHeader {
+					EncodeToText(w, err)
+				}
+			}()
+
+			next.ServeHTTP(tracked, r)
+		})
+	}
+}
+
+// trackingResponseWriter keeps track of whether the response was started.
+//
+// It implements http.Flusher and http.Hijacker, so that handlers that
+// assert them keep working. Use http.ResponseController to detect whether
+// the underlying ResponseWriter supports them.
+type trackingResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *trackingResponseWriter) WriteHeader(statusCode int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *trackingResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher.
+func (w *trackingResponseWriter) Flush() {
+	_ = w.FlushError()
+}
+
+// FlushError is like Flush, but it returns an error if the underlying
+// ResponseWriter does not support flushing. See http.ResponseController.
+func (w *trackingResponseWriter) FlushError() error {
+	err := http.NewResponseController(w.ResponseWriter).Flush()
+	if err == nil {
+		w.wroteHeader = true
+	}
+
+	return err
+}
+
+// Hijack implements http.Hijacker.
+func (w *trackingResponseWriter) Hijack() (
+	net.Conn,
+	*bufio.ReadWriter,
+	error,
+) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil {
+		w.wroteHeader = true
+	}
+
+	return conn, rw, err
+}
+
+// Unwrap supports http.ResponseController.
+func (w *trackingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,115 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecover_encodes_panics_as_unknown_errors(t *testing.T) {
+	var reported error
+	handler := httperror.Recover(func(_ *http.Request, err error) {
+		reported = err
+	})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("secret details")
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	handler.ServeHTTP(w, r)
+
+	if expected := http.StatusInternalServerError; w.Code != expected {
+		t.Errorf("expected %v, got %v", expected, w.Code)
+	}
+	if body := w.Body.String(); body != "Internal Server Error\n" {
+		t.Errorf("unexpected body: %q", body)
+	}
+	v, ok := apperror.PanicValue(reported)
+	if !ok || v != "secret details" {
+		t.Errorf("unexpected reported error: %v", reported)
+	}
+}
+
+func TestRecover_only_reports_panics_after_responding(t *testing.T) {
+	var reported error
+	handler := httperror.Recover(func(_ *http.Request, err error) {
+		reported = err
+	})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		panic("secret details")
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	handler.ServeHTTP(w, r)
+
+	if expected := http.StatusOK; w.Code != expected {
+		t.Errorf("expected %v, got %v", expected, w.Code)
+	}
+	if body := w.Body.String(); body != "partial" {
+		t.Errorf("unexpected body: %q", body)
+	}
+	if _, ok := apperror.PanicValue(reported); !ok {
+		t.Errorf("unexpected reported error: %v", reported)
+	}
+}
+
+func TestRecover_supports_flushers(t *testing.T) {
+	handler := httperror.Recover(nil)(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("expected an http.Flusher")
+		}
+
+		flusher.Flush()
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	handler.ServeHTTP(w, r)
+
+	if !w.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+}
+
+func TestRecover_does_not_interfere_without_panics(t *testing.T) {
+	handler := httperror.Recover(nil)(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		_ *http.Request,
+	) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	handler.ServeHTTP(w, r)
+
+	if expected := http.StatusAccepted; w.Code != expected {
+		t.Errorf("expected %v, got %v", expected, w.Code)
+	}
+}
+
+func TestRecover_propagates_aborts(t *testing.T) {
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Error("expected http.ErrAbortHandler, got:", r)
+		}
+	}()
+
+	handler := httperror.Recover(nil)(http.HandlerFunc(func(
+		http.ResponseWriter,
+		*http.Request,
+	) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,46 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// encodeRetryAfter sets the Retry-After header if the error carries a retry
+// delay. HTTP only supports whole seconds, so the delay is rounded up.
+func encodeRetryAfter(header http.Header, err error) {
+	delay, ok := apperror.RetryAfter(err)
+	if !ok {
+		return
+	}
+
+	seconds := int64(math.Ceil(delay.Seconds()))
+	header.Set(retryAfterHeader, strconv.FormatInt(seconds, 10))
+}
+
+// decodeRetryAfter attaches the delay from the Retry-After header, if any.
+// Both delay-seconds and HTTP-date values are supported.
+func decodeRetryAfter(header http.Header, err error) error {
+	value := header.Get(retryAfterHeader)
+	if value == "" {
+		return err
+	}
+
+	seconds, parseErr := strconv.ParseInt(value, 10, 64)
+	if parseErr == nil {
+		delay := time.Duration(seconds) * time.Second
+		return apperror.WithRetryAfter(err, delay)
+	}
+
+	date, parseErr := http.ParseTime(value)
+	if parseErr == nil {
+		return apperror.WithRetryAfter(err, time.Until(date))
+	}
+
+	// Malformed hints are not worth failing the decoding for.
+	return err
+}
+
+const retryAfterHeader = "Retry-After"
@@ -0,0 +1,109 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"artk.dev/httperror"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeToText_encodes_retry_after_header(t *testing.T) {
+	err := apperror.TooManyRequests(errorMessage)
+	err = apperror.WithRetryAfter(err, 1500*time.Millisecond)
+
+	w := httptest.NewRecorder()
+	httperror.EncodeToText(w, err)
+
+	// HTTP only supports whole seconds, so the delay is rounded up.
+	const expected = "2"
+	if got := w.Header().Get("Retry-After"); got != expected {
+		t.Errorf(`expected "%v", got "%v"`, expected, got)
+	}
+}
+
+func TestEncodeToText_omits_retry_after_header_without_delay(t *testing.T) {
+	w := httptest.NewRecorder()
+	httperror.EncodeToText(w, apperror.TooManyRequests(errorMessage))
+
+	if got := w.Header().Get("Retry-After"); got != "" {
+		t.Errorf("unexpected Retry-After header: %v", got)
+	}
+}
+
+func TestDecodeFromText_retry_after_encoding_is_reversible(t *testing.T) {
+	for _, kind := range []apperror.Kind{
+		apperror.TooManyRequestsError,
+		apperror.TimeoutError,
+	} {
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, errorMessage)
+			err = apperror.WithRetryAfter(err, 3*time.Second)
+			decodedErr := encodeAndDecode(err)
+
+			assertRetryAfter(t, decodedErr, 3*time.Second)
+			if got := apperror.KindOf(decodedErr); got != kind {
+				t.Errorf("expected %v, got %v", kind, got)
+			}
+		})
+	}
+}
+
+func TestDecodeFromText_supports_retry_after_dates(t *testing.T) {
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	err := decodeWithRetryAfter(date)
+
+	delay, ok := apperror.RetryAfter(err)
+	if !ok {
+		t.Fatal("missing retry delay")
+	}
+	if delay <= 59*time.Minute || delay > time.Hour {
+		t.Errorf("unexpected delay: %v", delay)
+	}
+}
+
+func TestDecodeFromText_past_retry_after_dates_are_zero(t *testing.T) {
+	date := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	err := decodeWithRetryAfter(date)
+	assertRetryAfter(t, err, 0)
+}
+
+func TestDecodeFromText_ignores_malformed_retry_after(t *testing.T) {
+	err := decodeWithRetryAfter("soon")
+
+	if _, ok := apperror.RetryAfter(err); ok {
+		t.Error("unexpected retry delay")
+	}
+	if !apperror.IsTooManyRequests(err) {
+		t.Error("unexpected kind:", apperror.KindOf(err))
+	}
+}
+
+func decodeWithRetryAfter(value string) error {
+	response := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{value}},
+		Body:       io.NopCloser(strings.NewReader(errorMessage)),
+	}
+	defer func() {
+		assume.Success(response.Body.Close())
+	}()
+
+	return httperror.DecodeFromText(response)
+}
+
+func assertRetryAfter(t *testing.T, err error, expected time.Duration) {
+	t.Helper()
+
+	got, ok := apperror.RetryAfter(err)
+	if !ok {
+		t.Fatal("missing retry delay")
+	}
+	if got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
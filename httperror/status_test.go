@@ -38,6 +38,38 @@ func TestDecodeKind_gateway_errors_are_timeouts(t *testing.T) {
 	}
 }
 
+func TestDecodeKind_service_unavailable_is_unavailable(t *testing.T) {
+	got := httperror.DecodeKind(http.StatusServiceUnavailable)
+	if expected := apperror.UnavailableError; expected != got {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestEncodeKind_canceled_is_client_closed_request(t *testing.T) {
+	// Non-standard, but widely used since nginx introduced it.
+	const expected = 499
+	got := httperror.EncodeKind(apperror.CanceledError)
+	if expected != got {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestStatusText_supports_client_closed_request(t *testing.T) {
+	for status, expected := range map[int]string{
+		499:                    "Client Closed Request",
+		http.StatusConflict:    "Conflict",
+		http.StatusBadGateway:  "Bad Gateway",
+		http.StatusNotExtended: "Not Extended",
+	} {
+		t.Run(strconv.Itoa(status), func(t *testing.T) {
+			got := httperror.StatusText(status)
+			if got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
 func TestDecodeKind_misc_client_errors_are_validation_errors(t *testing.T) {
 	// HTTP 405 is not one of the kinds considered by apperror because
 	// it lacks meaning outside of an HTTP interface.
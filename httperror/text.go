@@ -9,30 +9,43 @@ import (
 )
 
 // EncodeToText encodes an error into plain text.
+// Only the public message of the error is written. See apperror.PublicMessage.
+//
+// Retry delays are encoded in the Retry-After header. Reasons are encoded in
+// the Error-Reason and Error-Domain headers. Challenges of unauthorized
+// errors are encoded in the WWW-Authenticate header.
 // No further writes to the ResponseWriter w should happen after this function.
 func EncodeToText(w http.ResponseWriter, err error) {
+	DefaultMapper.EncodeToText(w, err)
+}
+
+// EncodeToText is like the EncodeToText function, but it uses the Mapper to map
+// kinds and status codes.
+func (m Mapper) EncodeToText(w http.ResponseWriter, err error) {
 	assume.NotZero(w)
 
 	kind := apperror.KindOf(err)
-	status := EncodeKind(kind)
+	status := m.EncodeHeader(w.Header(), err)
 
-	switch kind {
-	case apperror.OK:
-		// The only difference compared to just returning is that
-		// the content-type is set.
-		http.Error(w, "", status)
-	case apperror.UnknownError:
-		http.Error(w, "Internal Server Error", status)
-	default:
-		http.Error(w, err.Error(), status)
-	}
+	// For OK, the only difference compared to just returning is that
+	// the content-type is set.
+	http.Error(w, publicMessage(kind, status, err), status)
 }
 
 // DecodeFromText decodes an error from plain text.
+//
+// The kind is decoded from the Error-Kind header if present, or from the
+// status code otherwise. See Mapper.WithKindHeader.
 func DecodeFromText(response *http.Response) error {
+	return DefaultMapper.DecodeFromText(response)
+}
+
+// DecodeFromText is like the DecodeFromText function, but it uses the Mapper to
+// map kinds and status codes.
+func (m Mapper) DecodeFromText(response *http.Response) error {
 	assume.NotZero(response)
 
-	kind := DecodeKind(response.StatusCode)
+	kind := m.decodeResponseKind(response)
 	if kind == apperror.OK {
 		return nil
 	}
@@ -46,5 +59,42 @@ func DecodeFromText(response *http.Response) error {
 	msg := string(body)
 	msg = strings.TrimSuffix(msg, "\n")
 
-	return apperror.New(kind, msg)
+	err = apperror.New(kind, msg)
+	err = decodeRetryAfter(response.Header, err)
+	err = decodeChallenge(response.Header, err)
+	return decodeReason(response.Header, err)
+}
+
+// PublicMessage returns the message of an error that the encoders of this
+// package send to clients. It is like apperror.PublicMessage, but unknown
+// errors without a public message get the text of their status code, e.g.,
+// "Internal Server Error". See StatusText.
+func PublicMessage(err error) string {
+	return DefaultMapper.PublicMessage(err)
+}
+
+// PublicMessage is like the PublicMessage function, but it uses the Mapper to
+// map kinds and status codes.
+func (m Mapper) PublicMessage(err error) string {
+	kind := apperror.KindOf(err)
+	return publicMessage(kind, m.EncodeKind(kind), err)
+}
+
+// publicMessage returns the message that can be sent to clients.
+// It never leaks the internal error chain.
+func publicMessage(kind apperror.Kind, status int, err error) string {
+	switch kind {
+	case apperror.OK:
+		return ""
+	case apperror.UnknownError:
+		// Unknown errors are redacted unless a public message was
+		// explicitly provided.
+		msg := apperror.PublicMessage(err)
+		if msg == "" {
+			msg = StatusText(status)
+		}
+		return msg
+	default:
+		return apperror.PublicMessage(err)
+	}
 }
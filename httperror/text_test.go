@@ -6,6 +6,7 @@ import (
 	"artk.dev/broken"
 	"artk.dev/httperror"
 	"errors"
+	"fmt"
 	"io"
 	"mime"
 	"net/http"
@@ -110,6 +111,43 @@ func TestEncodeToText_redacts_the_message_of_unknown_errors(t *testing.T) {
 	}
 }
 
+func TestEncodeToText_does_not_leak_wrap_context(t *testing.T) {
+	err := apperror.NotFound(errorMessage)
+	err = fmt.Errorf("SELECT * FROM orders WHERE tenant = 42: %w", err)
+	w := httptest.NewRecorder()
+	httperror.EncodeToText(w, err)
+
+	got := strings.TrimSuffix(w.Body.String(), "\n")
+	if got != errorMessage {
+		t.Errorf(`expected "%v", got "%v"`, errorMessage, got)
+	}
+}
+
+func TestEncodeToText_encodes_explicit_public_message(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		if kind == apperror.OK {
+			// Special case: there is no error.
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, "internal details")
+			err = apperror.WithPublicMessage(err, errorMessage)
+			w := httptest.NewRecorder()
+			httperror.EncodeToText(w, err)
+
+			got := strings.TrimSuffix(w.Body.String(), "\n")
+			if got != errorMessage {
+				t.Errorf(
+					`expected "%v", got "%v"`,
+					errorMessage,
+					got,
+				)
+			}
+		})
+	}
+}
+
 func TestEncodeToText_panics_for_nil_response_writer(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -121,6 +159,24 @@ func TestEncodeToText_panics_for_nil_response_writer(t *testing.T) {
 	httperror.EncodeToText(nil, err)
 }
 
+func TestPublicMessage_redacts_unknown_errors(t *testing.T) {
+	for err, expected := range map[error]string{
+		apperror.Conflict(errorMessage): errorMessage,
+		errors.New(errorMessage):        "Internal Server Error",
+		apperror.WithPublicMessage(
+			errors.New("internal details"),
+			errorMessage,
+		): errorMessage,
+	} {
+		t.Run(expected, func(t *testing.T) {
+			got := httperror.PublicMessage(err)
+			if got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
 func TestDecodeFromText_kind_encoding_is_reversible(t *testing.T) {
 	for _, kind := range apperror.KindValues() {
 		t.Run(kind.String(), func(t *testing.T) {
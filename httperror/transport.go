@@ -0,0 +1,96 @@
+package httperror
+
+import (
+	"artk.dev/apperror"
+	"context"
+	"errors"
+	"mime"
+	"net"
+	"net/http"
+)
+
+// Transport is an http.RoundTripper that turns error responses into errors,
+// which is convenient for clients of services that use this package.
+//
+// Responses with status codes 400 and above are decoded according to their
+// Content-Type: DecodeFromProblem for application/problem+json,
+// DecodeFromJSON for application/json, and DecodeFromText otherwise.
+// Their bodies are closed, and no response is returned. Other responses,
+// such as redirects, are returned as is.
+//
+// Failures of the underlying transport are classified as well: timeouts as
+// apperror.TimeoutError, cancellations as apperror.CanceledError, and
+// network errors, e.g., refused connections or failed DNS lookups, as
+// apperror.UnavailableError.
+//
+// Note that this deviates from the contract of http.RoundTripper, which
+// expects a nil error for any response. It is meant to be the outermost
+// RoundTripper of an http.Client.
+type Transport struct {
+	// Base is the underlying RoundTripper.
+	// If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// Mapper is used to decode error responses.
+	// The zero value is equivalent to DefaultMapper.
+	Mapper Mapper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	response, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+
+	if response.StatusCode < http.StatusBadRequest {
+		return response, nil
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	return nil, t.decodeResponse(response)
+}
+
+// decodeResponse decodes an error response according to its Content-Type.
+func (t *Transport) decodeResponse(response *http.Response) error {
+	contentType := response.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case problemContentType:
+		return t.Mapper.DecodeFromProblem(response)
+	case jsonContentType:
+		return t.Mapper.DecodeFromJSON(response)
+	default:
+		return t.Mapper.DecodeFromText(response)
+	}
+}
+
+// classifyTransportError determines the kind of errors that happen before a
+// response is received.
+func classifyTransportError(err error) error {
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return apperror.AsCanceled(err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return apperror.AsTimeout(err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return apperror.AsTimeout(err)
+	case errors.As(err, &dnsErr), errors.As(err, &opErr):
+		return apperror.AsUnavailable(err)
+	default:
+		return err
+	}
+}
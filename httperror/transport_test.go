@@ -0,0 +1,272 @@
+package httperror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"artk.dev/httperror"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func ExampleTransport() {
+	server := httptest.NewServer(http.HandlerFunc(func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) {
+		err := apperror.NotFound("order not found")
+		httperror.EncodeToProblem(w, r, err)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &httperror.Transport{}}
+	response, err := client.Get(server.URL + "/orders/42")
+	if err == nil {
+		assume.Success(response.Body.Close())
+	}
+
+	fmt.Println("Not found:", apperror.IsNotFound(err))
+	fmt.Println("Public message:", apperror.PublicMessage(err))
+
+	// Output:
+	// Not found: true
+	// Public message: order not found
+}
+
+func TestTransport_decodes_error_responses(t *testing.T) {
+	encoders := map[string]httperror.Encoder{
+		"text":    ignoreRequest(httperror.EncodeToText),
+		"JSON":    ignoreRequest(httperror.EncodeToJSON),
+		"problem": httperror.EncodeToProblem,
+	}
+
+	for name, encode := range encoders {
+		t.Run(name, func(t *testing.T) {
+			for _, kind := range apperror.KindValues() {
+				if kind == apperror.OK {
+					// Special case: there is no error.
+					continue
+				}
+
+				assertTransportDecodes(t, encode, kind)
+			}
+		})
+	}
+}
+
+func assertTransportDecodes(
+	t *testing.T,
+	encode httperror.Encoder,
+	kind apperror.Kind,
+) {
+	t.Helper()
+
+	err := apperror.New(kind, errorMessage)
+	client := newTestClient(t, func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) {
+		encode(w, r, err)
+	})
+
+	if got := apperror.KindOf(get(client)); got != kind {
+		t.Errorf("expected %v, got %v", kind, got)
+	}
+}
+
+func TestTransport_returns_successful_responses(t *testing.T) {
+	for _, status := range []int{
+		http.StatusOK,
+		http.StatusNoContent,
+		http.StatusNotModified,
+	} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			client := newTestClient(t, func(
+				w http.ResponseWriter,
+				_ *http.Request,
+			) {
+				w.WriteHeader(status)
+			})
+
+			if err := get(client); err != nil {
+				t.Error("unexpected error:", err)
+			}
+		})
+	}
+}
+
+func TestTransport_follows_redirects(t *testing.T) {
+	client := newTestClient(t, func(
+		w http.ResponseWriter,
+		r *http.Request,
+	) {
+		if r.URL.Path == "/" {
+			http.Redirect(w, r, "/moved", http.StatusFound)
+			return
+		}
+
+		httperror.EncodeToText(w, apperror.Gone(errorMessage))
+	})
+
+	if err := get(client); !apperror.IsGone(err) {
+		t.Error("expected gone error, got:", err)
+	}
+}
+
+func TestTransport_classifies_timeouts(t *testing.T) {
+	client := newTestClient(t, waitForCancellation)
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		time.Millisecond,
+	)
+	defer cancel()
+
+	err := getWithContext(ctx, client)
+	if !apperror.IsTimeout(err) {
+		t.Error("expected timeout error, got:", err)
+	}
+}
+
+func TestTransport_classifies_cancellations(t *testing.T) {
+	client := newTestClient(t, waitForCancellation)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := getWithContext(ctx, client)
+	if !apperror.IsCanceled(err) {
+		t.Error("expected canceled error, got:", err)
+	}
+}
+
+func TestTransport_classifies_refused_connections(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client := &http.Client{Transport: &httperror.Transport{}}
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	assume.Success(err)
+
+	response, err := client.Do(request)
+	if err == nil {
+		assume.Success(response.Body.Close())
+	}
+	if !apperror.IsUnavailable(err) {
+		t.Error("expected unavailable error, got:", err)
+	}
+}
+
+func TestTransport_classifies_network_errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err      error
+		expected apperror.Kind
+	}{
+		"DNS": {
+			err: &net.DNSError{
+				Err:        "no such host",
+				IsNotFound: true,
+			},
+			expected: apperror.UnavailableError,
+		},
+		"DNS timeout": {
+			err: &net.DNSError{
+				Err:       "timeout",
+				IsTimeout: true,
+			},
+			expected: apperror.TimeoutError,
+		},
+		"dial": {
+			err: &net.OpError{
+				Op:  "dial",
+				Err: errors.New("connection refused"),
+			},
+			expected: apperror.UnavailableError,
+		},
+		"other": {
+			err:      errors.New(errorMessage),
+			expected: apperror.UnknownError,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			transport := &httperror.Transport{
+				Base: roundTripperFunc(func(
+					*http.Request,
+				) (*http.Response, error) {
+					return nil, tc.err
+				}),
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			_, err := transport.RoundTrip(request)
+			expected := tc.expected
+			if got := apperror.KindOf(err); got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(
+	r *http.Request,
+) (*http.Response, error) {
+	return f(r)
+}
+
+func ignoreRequest(
+	encode func(w http.ResponseWriter, err error),
+) httperror.Encoder {
+	return func(w http.ResponseWriter, _ *http.Request, err error) {
+		encode(w, err)
+	}
+}
+
+func waitForCancellation(_ http.ResponseWriter, r *http.Request) {
+	<-r.Context().Done()
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *testClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &testClient{
+		url:    server.URL,
+		client: &http.Client{Transport: &httperror.Transport{}},
+	}
+}
+
+type testClient struct {
+	url    string
+	client *http.Client
+}
+
+func get(c *testClient) error {
+	return getWithContext(context.Background(), c)
+}
+
+func getWithContext(ctx context.Context, c *testClient) error {
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		c.url,
+		nil,
+	)
+	assume.Success(err)
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
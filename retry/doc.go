@@ -0,0 +1,3 @@
+// Package retry repeats operations that fail with errors that are not final,
+// as defined by apperror.IsFinal.
+package retry
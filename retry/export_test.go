@@ -0,0 +1,4 @@
+package retry
+
+// Options allows tests to name the type of the With* functions.
+type Options = retryOptions
@@ -0,0 +1,230 @@
+package retry
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// Do calls op until one of the following happens:
+//
+//   - The operation succeeds.
+//   - The operation fails with a final error (see apperror.IsFinal).
+//   - The maximum number of attempts is reached.
+//   - The next attempt would happen after the maximum elapsed time.
+//   - The context is done.
+//
+// Attempts are separated by an exponential backoff with jitter. If an error
+// carries a retry delay (see apperror.RetryAfter), the next attempt will not
+// happen before that delay has passed.
+//
+// When giving up, Do returns the last error of the operation as is, so that
+// its kind is preserved. If the context is done before the first attempt,
+// Do returns the error of the context.
+func Do(
+	ctx context.Context,
+	op func(ctx context.Context) error,
+	optionsFn ...func(options *retryOptions),
+) error {
+	_, err := DoValue(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, op(ctx)
+	}, optionsFn...)
+	return err
+}
+
+// DoValue is like Do, but for operations that return a value.
+func DoValue[T any](
+	ctx context.Context,
+	op func(ctx context.Context) (T, error),
+	optionsFn ...func(options *retryOptions),
+) (T, error) {
+	o := newOptions(optionsFn)
+
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	start := o.now()
+	delay := o.initialDelay
+	for attempt := 1; ; attempt++ {
+		value, err := op(ctx)
+		if apperror.IsFinal(err) || attempt >= o.maxAttempts {
+			// Success is also final.
+			return value, err
+		}
+
+		wait := o.jittered(delay)
+		if hint, ok := apperror.RetryAfter(err); ok {
+			wait = max(wait, hint)
+		}
+
+		elapsed := o.now().Sub(start)
+		if o.maxElapsedTime > 0 && elapsed+wait > o.maxElapsedTime {
+			return value, err
+		}
+
+		if o.sleep(ctx, wait) != nil {
+			return value, err
+		}
+
+		delay = o.next(delay)
+	}
+}
+
+// WithMaxAttempts limits the number of attempts, including the first one.
+// It must be positive. The default is 5.
+func WithMaxAttempts(n int) func(options *retryOptions) {
+	assume.Truef(n > 0, "max attempts must be positive (was %v)", n)
+
+	return func(options *retryOptions) {
+		options.maxAttempts = n
+	}
+}
+
+// WithMaxElapsedTime prevents attempts that would start after the specified
+// time has elapsed since the first attempt. Zero, the default, means that
+// there is no limit.
+func WithMaxElapsedTime(d time.Duration) func(options *retryOptions) {
+	assume.Truef(d >= 0, "max elapsed time cannot be negative (was %v)", d)
+
+	return func(options *retryOptions) {
+		options.maxElapsedTime = d
+	}
+}
+
+// WithBackoff configures the exponential backoff.
+//
+// The delay before the second attempt is initial, and each further delay is
+// multiplied by multiplier, up to a maximum of maxDelay. The defaults are
+// 100ms, 2 and 10s, respectively.
+func WithBackoff(
+	initial time.Duration,
+	multiplier float64,
+	maxDelay time.Duration,
+) func(options *retryOptions) {
+	assume.Truef(initial >= 0, "initial delay cannot be negative")
+	assume.Truef(multiplier >= 1, "multiplier must be at least 1")
+	assume.Truef(maxDelay >= initial, "max delay must be at least initial")
+
+	return func(options *retryOptions) {
+		options.initialDelay = initial
+		options.multiplier = multiplier
+		options.maxDelay = maxDelay
+	}
+}
+
+// WithJitter sets the maximum fraction of each delay that is randomly
+// subtracted from it, which prevents synchronized retries across clients.
+// It must be between 0 and 1. The default is 0.2.
+func WithJitter(fraction float64) func(options *retryOptions) {
+	assume.Truef(
+		fraction >= 0 && fraction <= 1,
+		"jitter must be between 0 and 1 (was %v)",
+		fraction,
+	)
+
+	return func(options *retryOptions) {
+		options.jitter = fraction
+	}
+}
+
+// WithClock replaces time.Now, which is mostly useful in tests.
+func WithClock(now func() time.Time) func(options *retryOptions) {
+	assume.Truef(now != nil, "clock cannot be nil")
+
+	return func(options *retryOptions) {
+		options.now = now
+	}
+}
+
+// WithSleep replaces the function used to wait between attempts, which is
+// mostly useful in tests. It must return an error if the context is done
+// before the delay has passed.
+func WithSleep(
+	sleep func(ctx context.Context, d time.Duration) error,
+) func(options *retryOptions) {
+	assume.Truef(sleep != nil, "sleep cannot be nil")
+
+	return func(options *retryOptions) {
+		options.sleep = sleep
+	}
+}
+
+// WithRandom replaces the source of randomness used for jitter, which is
+// mostly useful in tests. The function must return values in [0, 1).
+func WithRandom(random func() float64) func(options *retryOptions) {
+	assume.Truef(random != nil, "random cannot be nil")
+
+	return func(options *retryOptions) {
+		options.random = random
+	}
+}
+
+// retryOptions configures Do and DoValue.
+// Use the With* functions to modify them.
+type retryOptions struct {
+	maxAttempts    int
+	maxElapsedTime time.Duration
+	initialDelay   time.Duration
+	maxDelay       time.Duration
+	multiplier     float64
+	jitter         float64
+	now            func() time.Time
+	sleep          func(ctx context.Context, d time.Duration) error
+	random         func() float64
+}
+
+func newOptions(optionsFn []func(options *retryOptions)) *retryOptions {
+	o := &retryOptions{
+		maxAttempts:  defaultMaxAttempts,
+		initialDelay: defaultInitialDelay,
+		maxDelay:     defaultMaxDelay,
+		multiplier:   defaultMultiplier,
+		jitter:       defaultJitter,
+		now:          time.Now,
+		sleep:        sleep,
+		random:       rand.Float64,
+	}
+	for _, fn := range optionsFn {
+		fn(o)
+	}
+
+	return o
+}
+
+func (o *retryOptions) next(d time.Duration) time.Duration {
+	// Clamp before converting back, since the product may overflow.
+	next := float64(d) * o.multiplier
+	if next >= float64(o.maxDelay) {
+		return o.maxDelay
+	}
+
+	return time.Duration(next)
+}
+
+func (o *retryOptions) jittered(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * (1 - o.jitter*o.random()))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+const (
+	defaultMaxAttempts  = 5
+	defaultInitialDelay = 100 * time.Millisecond
+	defaultMaxDelay     = 10 * time.Second
+	defaultMultiplier   = 2
+	defaultJitter       = 0.2
+)
@@ -0,0 +1,314 @@
+package retry_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/retry"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func ExampleDo() {
+	var attempts int
+	err := retry.Do(context.TODO(), func(_ context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return apperror.Timeoutf("timed out (%v)", attempts)
+		}
+
+		return nil
+	}, retry.WithBackoff(time.Nanosecond, 2, time.Microsecond))
+
+	fmt.Println("Attempts:", attempts)
+	fmt.Println("Error:", err)
+
+	// Output:
+	// Attempts: 3
+	// Error: <nil>
+}
+
+func TestDo_does_not_retry_success(t *testing.T) {
+	f := newFakeTime()
+	calls := f.run(t, func() error {
+		return nil
+	})
+	assertCalls(t, calls, 1)
+	f.assertDelays(t)
+}
+
+func TestDo_does_not_retry_final_errors(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		err := apperror.New(kind, "test error")
+		if err == nil || !apperror.IsFinal(err) {
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			f := newFakeTime()
+			calls := f.run(t, func() error {
+				return err
+			})
+			assertCalls(t, calls, 1)
+			f.assertDelays(t)
+		})
+	}
+}
+
+func TestDo_retries_transient_errors_up_to_max_attempts(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		err := apperror.New(kind, "test error")
+		if apperror.IsFinal(err) {
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			f := newFakeTime()
+			calls := f.run(t, func() error {
+				return err
+			}, retry.WithMaxAttempts(4))
+			assertCalls(t, calls, 4)
+		})
+	}
+}
+
+func TestDo_returns_the_last_error(t *testing.T) {
+	f := newFakeTime()
+	var i int
+	var last error
+	err := retry.Do(context.TODO(), func(_ context.Context) error {
+		i++
+		last = apperror.Timeoutf("attempt %v", i)
+		return last
+	}, f.options()...)
+
+	if err != last {
+		t.Errorf("expected %v, got %v", last, err)
+	}
+}
+
+func TestDo_uses_exponential_backoff(t *testing.T) {
+	f := newFakeTime()
+	f.run(t, func() error {
+		return apperror.Timeout("test error")
+	},
+		retry.WithMaxAttempts(6),
+		retry.WithBackoff(time.Second, 2, 10*time.Second),
+	)
+	f.assertDelays(
+		t,
+		time.Second,
+		2*time.Second,
+		4*time.Second,
+		8*time.Second,
+		10*time.Second,
+	)
+}
+
+func TestDo_backoff_does_not_overflow(t *testing.T) {
+	const initial, maxDelay = time.Duration(1 << 61), time.Duration(1 << 62)
+
+	f := newFakeTime()
+	f.run(t, func() error {
+		return apperror.Timeout("test error")
+	},
+		retry.WithMaxAttempts(3),
+		retry.WithBackoff(initial, 1e10, maxDelay),
+	)
+	f.assertDelays(t, initial, maxDelay)
+}
+
+func TestDo_applies_jitter(t *testing.T) {
+	f := newFakeTime()
+	f.run(t, func() error {
+		return apperror.Timeout("test error")
+	},
+		retry.WithMaxAttempts(3),
+		retry.WithBackoff(time.Second, 2, 10*time.Second),
+		retry.WithJitter(0.5),
+		retry.WithRandom(func() float64 { return 0.5 }),
+	)
+
+	// Each delay is reduced by 0.5 * 0.5 = 25%.
+	f.assertDelays(t, 750*time.Millisecond, 1500*time.Millisecond)
+}
+
+func TestDo_honors_retry_after(t *testing.T) {
+	f := newFakeTime()
+	f.run(t, func() error {
+		err := apperror.TooManyRequests("test error")
+		return apperror.WithRetryAfter(err, 3*time.Second)
+	},
+		retry.WithMaxAttempts(3),
+		retry.WithBackoff(time.Second, 4, 10*time.Second),
+	)
+
+	// The hint is a minimum: longer backoffs are preserved.
+	f.assertDelays(t, 3*time.Second, 4*time.Second)
+}
+
+func TestDo_honors_max_elapsed_time(t *testing.T) {
+	f := newFakeTime()
+	calls := f.run(t, func() error {
+		return apperror.Timeout("test error")
+	},
+		retry.WithMaxAttempts(100),
+		retry.WithBackoff(time.Second, 2, 10*time.Second),
+		retry.WithMaxElapsedTime(10*time.Second),
+	)
+
+	// After 1s + 2s + 4s = 7s, waiting 8s more would exceed 10s.
+	assertCalls(t, calls, 4)
+	f.assertDelays(t, time.Second, 2*time.Second, 4*time.Second)
+}
+
+func TestDo_stops_when_the_context_is_done(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f := newFakeTime()
+	var calls int
+	expected := apperror.Timeout("test error")
+	err := retry.Do(ctx, func(_ context.Context) error {
+		calls++
+		cancel()
+		return expected
+	}, f.options()...)
+
+	assertCalls(t, calls, 1)
+	if err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestDo_returns_context_error_before_first_attempt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls int
+	err := retry.Do(ctx, func(_ context.Context) error {
+		calls++
+		return nil
+	})
+
+	assertCalls(t, calls, 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestDo_default_sleep_respects_context(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Millisecond)
+	defer cancel()
+
+	var calls int
+	err := retry.Do(ctx, func(_ context.Context) error {
+		calls++
+		return apperror.Timeout("test error")
+	}, retry.WithBackoff(time.Hour, 1, time.Hour))
+
+	assertCalls(t, calls, 1)
+	if !apperror.IsTimeout(err) {
+		t.Error("unexpected error:", err)
+	}
+}
+
+func TestDoValue_returns_value(t *testing.T) {
+	f := newFakeTime()
+	var calls int
+	value, err := retry.DoValue(
+		context.TODO(),
+		func(_ context.Context) (int, error) {
+			calls++
+			if calls < 2 {
+				return 0, apperror.Unavailable("test error")
+			}
+
+			return 42, nil
+		},
+		f.options()...,
+	)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if value != 42 {
+		t.Errorf("expected 42, got %v", value)
+	}
+}
+
+func TestWithMaxAttempts_panics_if_not_positive(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("missing expected panic")
+		}
+	}()
+
+	retry.WithMaxAttempts(0)
+}
+
+// fakeTime records delays instead of sleeping and advances a fake clock.
+type fakeTime struct {
+	now    time.Time
+	delays []time.Duration
+}
+
+func newFakeTime() *fakeTime {
+	return &fakeTime{
+		now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (f *fakeTime) options() []func(options *retry.Options) {
+	return []func(options *retry.Options){
+		retry.WithClock(func() time.Time {
+			return f.now
+		}),
+		retry.WithSleep(f.sleep),
+		retry.WithRandom(func() float64 {
+			return 0
+		}),
+	}
+}
+
+func (f *fakeTime) sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.delays = append(f.delays, d)
+	f.now = f.now.Add(d)
+	return nil
+}
+
+func (f *fakeTime) run(
+	t *testing.T,
+	fn func() error,
+	optionsFn ...func(options *retry.Options),
+) int {
+	t.Helper()
+
+	var calls int
+	_ = retry.Do(context.TODO(), func(_ context.Context) error {
+		calls++
+		return fn()
+	}, append(f.options(), optionsFn...)...)
+	return calls
+}
+
+func (f *fakeTime) assertDelays(t *testing.T, expected ...time.Duration) {
+	t.Helper()
+
+	if !slices.Equal(f.delays, expected) {
+		t.Errorf("expected delays %v, got %v", expected, f.delays)
+	}
+}
+
+func assertCalls(t *testing.T, got, expected int) {
+	t.Helper()
+
+	if got != expected {
+		t.Errorf("expected %v calls, got %v", expected, got)
+	}
+}
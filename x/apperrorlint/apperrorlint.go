@@ -0,0 +1,105 @@
+// Package apperrorlint provides a static analyzer that detects common misuse
+// of artk.dev/apperror:
+//
+//   - Formatted constructors, such as apperror.Conflictf, that receive an
+//     error argument without the %w verb. The error is flattened into the
+//     message and its kind, among others, is lost.
+//   - Calls to apperror.As(apperror.OK, err), which silently discard err.
+//   - Exported functions of repository packages that return errors created
+//     with errors.New, which have no kind. A repository package is one that
+//     declares a type with all the methods of crud.Repository.
+//   - Switch statements over apperror.Kind that do not handle every known
+//     kind, even if they have a default case.
+//
+// The analyzer can be run with go vet, using the apperrorlint command as the
+// vet tool, or with golangci-lint as a module plugin.
+package apperrorlint
+
+import (
+	"go/ast"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer detects misuse of artk.dev/apperror.
+var Analyzer = &analysis.Analyzer{
+	Name:     "apperrorlint",
+	Doc:      "detect misuse of artk.dev/apperror",
+	URL:      "https://pkg.go.dev/artk.dev/x/apperrorlint",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	isRepository := isRepositoryPackage(pass.Pkg)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+		(*ast.FuncDecl)(nil),
+		(*ast.SwitchStmt)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			checkCall(pass, n)
+		case *ast.FuncDecl:
+			if isRepository {
+				checkRepositoryFunc(pass, n)
+			}
+		case *ast.SwitchStmt:
+			checkKindSwitch(pass, n)
+		}
+	})
+
+	return nil, nil
+}
+
+// checkCall checks calls to functions of artk.dev/apperror.
+func checkCall(pass *analysis.Pass, call *ast.CallExpr) {
+	fn, ok := calledFunc(pass, call)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != apperrorPath {
+		return
+	}
+
+	if fn.Name() == "As" {
+		checkDiscard(pass, call, fn)
+		return
+	}
+
+	checkWrapVerbs(pass, call, fn)
+}
+
+// calledFunc returns the function or method called by call, if it can be
+// determined statically.
+func calledFunc(pass *analysis.Pass, call *ast.CallExpr) (*types.Func, bool) {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil, false
+	}
+
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	return fn, ok
+}
+
+// isKind reports whether t is apperror.Kind.
+func isKind(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+	return obj.Pkg() != nil &&
+		obj.Pkg().Path() == apperrorPath &&
+		obj.Name() == "Kind"
+}
+
+const apperrorPath = "artk.dev/apperror"
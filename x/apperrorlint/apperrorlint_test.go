@@ -0,0 +1,44 @@
+package apperrorlint_test
+
+import (
+	"artk.dev/x/apperrorlint"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"testing"
+)
+
+func TestAnalyzer_wrap_verbs(t *testing.T) {
+	analysistest.Run(
+		t,
+		analysistest.TestData(),
+		apperrorlint.Analyzer,
+		"wrap",
+	)
+}
+
+func TestAnalyzer_discarded_errors(t *testing.T) {
+	analysistest.Run(
+		t,
+		analysistest.TestData(),
+		apperrorlint.Analyzer,
+		"discard",
+	)
+}
+
+func TestAnalyzer_repository_errors(t *testing.T) {
+	analysistest.Run(
+		t,
+		analysistest.TestData(),
+		apperrorlint.Analyzer,
+		"repository",
+		"service",
+	)
+}
+
+func TestAnalyzer_kind_switches(t *testing.T) {
+	analysistest.Run(
+		t,
+		analysistest.TestData(),
+		apperrorlint.Analyzer,
+		"kindswitch",
+	)
+}
@@ -0,0 +1,15 @@
+// Command apperrorlint detects misuse of artk.dev/apperror.
+//
+// It can be run directly or as a vet tool:
+//
+//	go vet -vettool=$(which apperrorlint) ./...
+package main
+
+import (
+	"artk.dev/x/apperrorlint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(apperrorlint.Analyzer)
+}
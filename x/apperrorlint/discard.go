@@ -0,0 +1,33 @@
+package apperrorlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkDiscard reports calls to apperror.As(apperror.OK, err), which always
+// return nil and therefore discard err.
+func checkDiscard(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) {
+	if len(call.Args) != 2 {
+		return
+	}
+
+	ok, isConst := fn.Pkg().Scope().Lookup("OK").(*types.Const)
+	if !isConst {
+		return
+	}
+
+	kind := pass.TypesInfo.Types[call.Args[0]].Value
+	if kind == nil || !constant.Compare(kind, token.EQL, ok.Val()) {
+		return
+	}
+
+	pass.Reportf(
+		call.Pos(),
+		"apperror.As(apperror.OK, err) always returns nil,"+
+			" discarding err",
+	)
+}
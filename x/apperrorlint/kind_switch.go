@@ -0,0 +1,82 @@
+package apperrorlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"slices"
+	"strings"
+)
+
+// checkKindSwitch reports switch statements over apperror.Kind that do not
+// handle every known kind. A default case does not count as handling the
+// missing kinds, since it also hides kinds added in later versions.
+func checkKindSwitch(pass *analysis.Pass, stmt *ast.SwitchStmt) {
+	if stmt.Tag == nil {
+		return
+	}
+
+	t := pass.TypesInfo.TypeOf(stmt.Tag)
+	if !isKind(t) {
+		return
+	}
+
+	var handled []constant.Value
+	for _, clause := range stmt.Body.List {
+		for _, expr := range clause.(*ast.CaseClause).List {
+			value := pass.TypesInfo.Types[expr].Value
+			if value != nil {
+				handled = append(handled, value)
+			}
+		}
+	}
+
+	var missing []string
+	for _, kind := range kindConstants(t.(*types.Named)) {
+		if !containsValue(handled, kind.Val()) {
+			missing = append(missing, kind.Name())
+		}
+	}
+
+	if len(missing) > 0 {
+		pass.Reportf(
+			stmt.Pos(),
+			"switch over apperror.Kind is missing cases for %s",
+			strings.Join(missing, ", "),
+		)
+	}
+}
+
+func containsValue(values []constant.Value, x constant.Value) bool {
+	return slices.ContainsFunc(values, func(v constant.Value) bool {
+		return constant.Compare(v, token.EQL, x)
+	})
+}
+
+// kindConstants returns the exported constants of type apperror.Kind, which
+// are the same as those returned by apperror.KindValues, sorted by value.
+func kindConstants(kind *types.Named) []*types.Const {
+	var kinds []*types.Const
+	scope := kind.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if ok && c.Exported() && types.Identical(c.Type(), kind) {
+			kinds = append(kinds, c)
+		}
+	}
+
+	slices.SortFunc(kinds, func(a, b *types.Const) int {
+		switch {
+		case constant.Compare(a.Val(), token.LSS, b.Val()):
+			return -1
+		case constant.Compare(a.Val(), token.GTR, b.Val()):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return kinds
+}
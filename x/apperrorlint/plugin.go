@@ -0,0 +1,26 @@
+package apperrorlint
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+)
+
+func init() {
+	register.Plugin(Analyzer.Name, newPlugin)
+}
+
+// plugin integrates the Analyzer with golangci-lint as a module plugin.
+// It has no settings.
+type plugin struct{}
+
+func newPlugin(any) (register.LinterPlugin, error) {
+	return plugin{}, nil
+}
+
+func (plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{Analyzer}, nil
+}
+
+func (plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
@@ -0,0 +1,91 @@
+package apperrorlint
+
+import (
+	"go/ast"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+)
+
+// isRepositoryPackage reports whether a package declares a type that has all
+// the methods of crud.Repository.
+func isRepositoryPackage(pkg *types.Package) bool {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+
+		if hasRepositoryMethods(types.NewPointer(obj.Type())) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasRepositoryMethods(t types.Type) bool {
+	methods := types.NewMethodSet(t)
+	for _, name := range repositoryMethods {
+		if methods.Lookup(nil, name) == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkRepositoryFunc reports exported functions and methods that return
+// errors created with errors.New, which have no kind.
+func checkRepositoryFunc(pass *analysis.Pass, decl *ast.FuncDecl) {
+	if !decl.Name.IsExported() || decl.Body == nil {
+		return
+	}
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Their results are not returned by decl.
+			return false
+		case *ast.ReturnStmt:
+			checkReturnedErrors(pass, decl, n)
+		}
+
+		return true
+	})
+}
+
+func checkReturnedErrors(
+	pass *analysis.Pass,
+	decl *ast.FuncDecl,
+	stmt *ast.ReturnStmt,
+) {
+	for _, result := range stmt.Results {
+		if !isErrorsNew(pass, result) {
+			continue
+		}
+
+		pass.Reportf(
+			result.Pos(),
+			"%s returns an error without a kind;"+
+				" use an apperror constructor",
+			decl.Name.Name,
+		)
+	}
+}
+
+func isErrorsNew(pass *analysis.Pass, expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	fn, ok := calledFunc(pass, call)
+	return ok &&
+		fn.Pkg() != nil &&
+		fn.Pkg().Path() == "errors" &&
+		fn.Name() == "New"
+}
+
+// repositoryMethods contains the method names of crud.Repository.
+var repositoryMethods = []string{"Get", "Insert", "Update", "Upsert", "Delete"}
@@ -0,0 +1,33 @@
+// Package apperror is a minimal stand-in for artk.dev/apperror.
+package apperror
+
+import "fmt"
+
+type Kind int
+
+const (
+	OK Kind = iota
+	UnknownError
+	NotFoundError
+	ConflictError
+)
+
+func As(kind Kind, err error) error {
+	if kind == OK {
+		return nil
+	}
+
+	return err
+}
+
+func New(kind Kind, msg string) error {
+	return fmt.Errorf("%s", msg)
+}
+
+func Newf(kind Kind, msg string, a ...any) error {
+	return fmt.Errorf(msg, a...)
+}
+
+func Conflictf(msg string, a ...any) error {
+	return fmt.Errorf(msg, a...)
+}
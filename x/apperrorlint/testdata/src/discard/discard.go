@@ -0,0 +1,15 @@
+package discard
+
+import (
+	"artk.dev/apperror"
+	"errors"
+)
+
+func examples(kind apperror.Kind) {
+	err := errors.New("example")
+	_ = apperror.As(apperror.OK, err) // want `apperror.As\(apperror.OK, err\) always returns nil, discarding err`
+	_ = apperror.As(0, err)           // want `apperror.As\(apperror.OK, err\) always returns nil, discarding err`
+
+	_ = apperror.As(apperror.ConflictError, err)
+	_ = apperror.As(kind, err)
+}
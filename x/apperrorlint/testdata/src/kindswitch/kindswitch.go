@@ -0,0 +1,34 @@
+package kindswitch
+
+import "artk.dev/apperror"
+
+func examples(kind apperror.Kind) {
+	switch kind { // want `switch over apperror.Kind is missing cases for UnknownError, ConflictError`
+	case apperror.OK:
+	case apperror.NotFoundError:
+	}
+
+	switch kind {
+	case apperror.OK, apperror.UnknownError:
+	case apperror.NotFoundError, apperror.ConflictError:
+	}
+
+	switch kind { // want `switch over apperror.Kind is missing cases for UnknownError, NotFoundError, ConflictError`
+	case apperror.OK:
+	default:
+	}
+
+	switch kind {
+	case apperror.OK, apperror.UnknownError:
+	case apperror.NotFoundError, apperror.ConflictError:
+	default:
+	}
+
+	switch {
+	case kind == apperror.OK:
+	}
+
+	switch x := 1; x {
+	case 1:
+	}
+}
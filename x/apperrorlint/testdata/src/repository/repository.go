@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"artk.dev/apperror"
+	"context"
+	"errors"
+)
+
+type Entity struct{}
+
+type Repository struct{}
+
+func (r *Repository) Get(ctx context.Context, id int) (*Entity, error) {
+	return nil, errors.New("not found") // want `Get returns an error without a kind; use an apperror constructor`
+}
+
+func (r *Repository) Insert(ctx context.Context, x *Entity) error {
+	return apperror.New(apperror.ConflictError, "already exists")
+}
+
+func (r *Repository) Update(
+	ctx context.Context,
+	id int,
+	update func(x *Entity) error,
+) error {
+	return update(&Entity{})
+}
+
+func (r *Repository) Upsert(
+	ctx context.Context,
+	id int,
+	insert func() (*Entity, error),
+	update func(x *Entity) error,
+) error {
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id int) error {
+	f := func() error {
+		return errors.New("ignored in function literals")
+	}
+
+	return f()
+}
+
+func New() (*Repository, error) {
+	return nil, (errors.New("cannot connect")) // want `New returns an error without a kind; use an apperror constructor`
+}
+
+func unexported() error {
+	return errors.New("ignored in unexported functions")
+}
@@ -0,0 +1,8 @@
+package service
+
+import "errors"
+
+// Not a repository package, so errors.New is allowed.
+func Do() error {
+	return errors.New("example")
+}
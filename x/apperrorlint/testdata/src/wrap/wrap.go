@@ -0,0 +1,20 @@
+package wrap
+
+import (
+	"artk.dev/apperror"
+	"errors"
+)
+
+var errExample = errors.New("example")
+
+func examples(args []any) {
+	_ = apperror.Conflictf("cannot save: %v", errExample)       // want `apperror.Conflictf formats an error with %v instead of %w`
+	_ = apperror.Conflictf("%d: %s", 42, errExample)            // want `apperror.Conflictf formats an error with %s instead of %w`
+	_ = apperror.Newf(apperror.NotFoundError, "%v", errExample) // want `apperror.Newf formats an error with %v instead of %w`
+	_ = apperror.Conflictf("%*d%% %v", 3, 42, errExample)       // want `apperror.Conflictf formats an error with %v instead of %w`
+
+	_ = apperror.Conflictf("cannot save: %w", errExample)
+	_ = apperror.Conflictf("cannot save: %v", "not an error")
+	_ = apperror.Conflictf("%[1]v", errExample)
+	_ = apperror.Conflictf("cannot save: %v", args...)
+}
@@ -0,0 +1,124 @@
+package apperrorlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"golang.org/x/tools/go/analysis"
+	"strings"
+	"unicode/utf8"
+)
+
+// checkWrapVerbs reports error arguments of formatted constructors, such as
+// apperror.Newf or apperror.Conflictf, that are not formatted with %w.
+func checkWrapVerbs(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) {
+	formatIndex, ok := formatParamIndex(fn)
+	if !ok || call.Ellipsis.IsValid() || formatIndex >= len(call.Args) {
+		return
+	}
+
+	format := pass.TypesInfo.Types[call.Args[formatIndex]].Value
+	if format == nil || format.Kind() != constant.String {
+		return
+	}
+
+	verbs, ok := formatVerbs(constant.StringVal(format))
+	if !ok {
+		return
+	}
+
+	args := call.Args[formatIndex+1:]
+	for i, verb := range verbs {
+		if i >= len(args) {
+			return
+		}
+
+		if verb == 'w' || verb == '*' {
+			continue
+		}
+
+		if isError(pass.TypesInfo.TypeOf(args[i])) {
+			pass.Reportf(
+				args[i].Pos(),
+				"apperror.%s formats an error with %%%c"+
+					" instead of %%w,"+
+					" which discards its kind",
+				fn.Name(),
+				verb,
+			)
+		}
+	}
+}
+
+// formatParamIndex returns the index of the format parameter of a function
+// with a printf-like signature, i.e., (..., format string, a ...any).
+func formatParamIndex(fn *types.Func) (int, bool) {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || !sig.Variadic() || sig.Params().Len() < 2 {
+		return 0, false
+	}
+
+	index := sig.Params().Len() - 2
+	format, ok := sig.Params().At(index).Type().(*types.Basic)
+	if !ok || format.Kind() != types.String {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// formatVerbs returns the verbs of a format string, in the order in which
+// they consume arguments. Arguments consumed by * are reported as '*'.
+//
+// It gives up on explicit argument indexes, which are rarely used.
+func formatVerbs(format string) ([]rune, bool) {
+	var verbs []rune
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		i++
+		for i < len(format) && isFormatFlag(format[i]) {
+			if format[i] == '*' {
+				verbs = append(verbs, '*')
+			}
+			i++
+		}
+
+		switch {
+		case i >= len(format):
+			return verbs, true
+		case format[i] == '[':
+			return nil, false
+		case format[i] == '%':
+			continue
+		}
+
+		verb, size := utf8.DecodeRuneInString(format[i:])
+		verbs = append(verbs, verb)
+		i += size - 1
+	}
+
+	return verbs, true
+}
+
+func isFormatFlag(c byte) bool {
+	return strings.IndexByte(formatFlags, c) >= 0
+}
+
+// isError reports whether values of type t are errors.
+func isError(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+
+	return types.Implements(t, errorInterface)
+}
+
+var errorInterface = types.Universe.Lookup("error").
+	Type().
+	Underlying().(*types.Interface)
+
+// formatFlags contains the characters that can appear between % and the verb.
+const formatFlags = "+-# 0123456789.*"
@@ -0,0 +1,143 @@
+package grpcerror
+
+import (
+	"artk.dev/apperror"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// encodeDetails attaches the metadata of an application error to a gRPC
+// status as standard google.rpc detail messages.
+func encodeDetails(s *status.Status, err error) *status.Status {
+	var details []protoadapt.MessageV1
+	if delay, ok := apperror.RetryAfter(err); ok {
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(delay),
+		})
+	}
+	if reason, ok := apperror.ReasonOf(err); ok {
+		details = append(details, &errdetails.ErrorInfo{
+			Reason: reason.Code,
+			Domain: reason.Domain,
+		})
+	}
+	if violations := apperror.Violations(err); len(violations) > 0 {
+		details = append(details, encodeViolations(violations))
+	}
+	if msg, ok := explicitPublicMessage(err); ok {
+		details = append(details, &errdetails.LocalizedMessage{
+			Locale:  unknownLocale,
+			Message: msg,
+		})
+	}
+
+	if len(details) == 0 {
+		return s
+	}
+
+	withDetails, detailsErr := s.WithDetails(details...)
+	if detailsErr != nil {
+		// The details are a best-effort addition. Losing them is
+		// preferable to losing the error.
+		return s
+	}
+
+	return withDetails
+}
+
+// decodeDetails reconstructs the metadata of an application error from the
+// google.rpc detail messages of a gRPC status.
+func decodeDetails(s *status.Status, err error) error {
+	for _, detail := range s.Details() {
+		switch d := detail.(type) {
+		case *errdetails.RetryInfo:
+			delay := d.GetRetryDelay().AsDuration()
+			err = apperror.WithRetryAfter(err, delay)
+		case *errdetails.ErrorInfo:
+			err = apperror.WithReason(err, apperror.Reason{
+				Code:   d.GetReason(),
+				Domain: d.GetDomain(),
+			})
+		case *errdetails.LocalizedMessage:
+			err = apperror.WithPublicMessage(err, d.GetMessage())
+		}
+	}
+
+	return decodeViolations(s, err)
+}
+
+func encodeViolations(violations []apperror.Violation) *errdetails.BadRequest {
+	fieldViolations := make(
+		[]*errdetails.BadRequest_FieldViolation,
+		0,
+		len(violations),
+	)
+	for _, v := range violations {
+		fieldViolations = append(
+			fieldViolations,
+			&errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Reason:      v.Reason,
+				Description: v.Message,
+			},
+		)
+	}
+
+	return &errdetails.BadRequest{FieldViolations: fieldViolations}
+}
+
+// decodeViolations attaches the violations of the BadRequest detail, if any.
+// Violations would turn other kinds into validation errors, so they are
+// ignored for them.
+func decodeViolations(s *status.Status, err error) error {
+	if !apperror.IsValidation(err) {
+		return err
+	}
+
+	var violations []apperror.Violation
+	for _, detail := range s.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, v := range badRequest.GetFieldViolations() {
+			violations = append(violations, apperror.Violation{
+				Field:   v.GetField(),
+				Reason:  v.GetReason(),
+				Message: v.GetDescription(),
+			})
+		}
+	}
+
+	if len(violations) == 0 {
+		return err
+	}
+
+	return apperror.WithViolations(err, violations...)
+}
+
+// explicitPublicMessage returns the message attached with
+// apperror.WithPublicMessage, if any. Unlike the status message, it is
+// preserved for unknown errors, which are otherwise redacted.
+//
+// Empty messages are ignored, e.g., those of errors translated into unknown
+// errors by apperror.Boundary, which hide the original public message.
+func explicitPublicMessage(err error) (string, bool) {
+	var target interface {
+		PublicMessage() string
+	}
+	if !errors.As(err, &target) {
+		return "", false
+	}
+
+	msg := target.PublicMessage()
+	return msg, msg != ""
+}
+
+// unknownLocale is the BCP 47 tag for an undetermined language. Public
+// messages do not declare their language.
+const unknownLocale = "und"
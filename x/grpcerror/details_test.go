@@ -0,0 +1,289 @@
+package grpcerror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/x/grpcerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestEncode_encodes_retry_after_as_retry_info(t *testing.T) {
+	err := apperror.TooManyRequests(errorMessage)
+	err = apperror.WithRetryAfter(err, 3*time.Second)
+	grpcStatus := asGRPCError(t, grpcerror.Encode(err))
+
+	var found bool
+	for _, detail := range grpcStatus.Details() {
+		info, ok := detail.(*errdetails.RetryInfo)
+		if !ok {
+			continue
+		}
+
+		found = true
+		got := info.GetRetryDelay().AsDuration()
+		if expected := 3 * time.Second; got != expected {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	}
+	if !found {
+		t.Error("missing RetryInfo detail")
+	}
+}
+
+func TestEncode_omits_retry_info_without_retry_after(t *testing.T) {
+	err := apperror.TooManyRequests(errorMessage)
+	grpcStatus := asGRPCError(t, grpcerror.Encode(err))
+
+	if details := grpcStatus.Details(); len(details) != 0 {
+		t.Errorf("unexpected details: %v", details)
+	}
+}
+
+func TestDecode_preserves_retry_after(t *testing.T) {
+	for _, kind := range []apperror.Kind{
+		apperror.TooManyRequestsError,
+		apperror.TimeoutError,
+	} {
+		t.Run(kind.String(), func(t *testing.T) {
+			const expected = 1500 * time.Millisecond
+			err := apperror.New(kind, errorMessage)
+			err = apperror.WithRetryAfter(err, expected)
+			decodedErr := grpcerror.Decode(grpcerror.Encode(err))
+
+			delay, ok := apperror.RetryAfter(decodedErr)
+			if !ok {
+				t.Fatal("missing retry delay")
+			}
+			if delay != expected {
+				t.Errorf("expected %v, got %v", expected, delay)
+			}
+			if got := apperror.KindOf(decodedErr); got != kind {
+				t.Errorf("expected %v, got %v", kind, got)
+			}
+		})
+	}
+}
+
+func TestEncode_encodes_reason_as_error_info(t *testing.T) {
+	err := apperror.WithReason(apperror.Conflict(errorMessage), testReason)
+	grpcStatus := asGRPCError(t, grpcerror.Encode(err))
+
+	var found bool
+	for _, detail := range grpcStatus.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+
+		found = true
+		if got := info.GetReason(); got != testReason.Code {
+			t.Errorf("expected %v, got %v", testReason.Code, got)
+		}
+		if got := info.GetDomain(); got != testReason.Domain {
+			t.Errorf("expected %v, got %v", testReason.Domain, got)
+		}
+	}
+	if !found {
+		t.Error("missing ErrorInfo detail")
+	}
+}
+
+func TestDecode_preserves_reason(t *testing.T) {
+	err := apperror.WithReason(apperror.Conflict(errorMessage), testReason)
+	decodedErr := grpcerror.Decode(grpcerror.Encode(err))
+
+	got, ok := apperror.ReasonOf(decodedErr)
+	if !ok {
+		t.Fatal("missing reason")
+	}
+	if got != testReason {
+		t.Errorf("expected %v, got %v", testReason, got)
+	}
+	if !apperror.IsConflict(decodedErr) {
+		t.Error("unexpected kind:", apperror.KindOf(decodedErr))
+	}
+}
+
+func TestEncode_encodes_violations_as_bad_request(t *testing.T) {
+	err := apperror.WithViolations(
+		apperror.Validation(errorMessage),
+		testViolations...,
+	)
+	grpcStatus := asGRPCError(t, grpcerror.Encode(err))
+
+	var found bool
+	for _, detail := range grpcStatus.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		found = true
+		got := badRequest.GetFieldViolations()
+		if len(got) != len(testViolations) {
+			t.Fatalf("expected %v, got %v", testViolations, got)
+		}
+		for i, expected := range testViolations {
+			v := got[i]
+			if v.GetField() != expected.Field ||
+				v.GetReason() != expected.Reason ||
+				v.GetDescription() != expected.Message {
+				t.Errorf("expected %v, got %v", expected, v)
+			}
+		}
+	}
+	if !found {
+		t.Error("missing BadRequest detail")
+	}
+}
+
+func TestDecode_preserves_violations(t *testing.T) {
+	err := apperror.WithViolations(
+		apperror.Validation(errorMessage),
+		testViolations...,
+	)
+	decodedErr := grpcerror.Decode(grpcerror.Encode(err))
+
+	got := apperror.Violations(decodedErr)
+	if !slices.Equal(got, testViolations) {
+		t.Errorf("expected %v, got %v", testViolations, got)
+	}
+	if !apperror.IsValidation(decodedErr) {
+		t.Error("unexpected kind:", apperror.KindOf(decodedErr))
+	}
+}
+
+func TestDecode_ignores_violations_of_other_kinds(t *testing.T) {
+	type fieldViolation = errdetails.BadRequest_FieldViolation
+	s, err := status.New(codes.NotFound, errorMessage).WithDetails(
+		&errdetails.BadRequest{
+			FieldViolations: []*fieldViolation{
+				{Field: "id", Description: "not found"},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	decodedErr := grpcerror.Decode(s.Err())
+	if got := apperror.Violations(decodedErr); got != nil {
+		t.Errorf("unexpected violations: %v", got)
+	}
+	if !apperror.IsNotFound(decodedErr) {
+		t.Error("unexpected kind:", apperror.KindOf(decodedErr))
+	}
+}
+
+func TestEncode_encodes_public_message_as_localized_message(t *testing.T) {
+	err := apperror.Unknown("internal details")
+	err = apperror.WithPublicMessage(err, errorMessage)
+	grpcStatus := asGRPCError(t, grpcerror.Encode(err))
+
+	var found bool
+	for _, detail := range grpcStatus.Details() {
+		msg, ok := detail.(*errdetails.LocalizedMessage)
+		if !ok {
+			continue
+		}
+
+		found = true
+		if got := msg.GetMessage(); got != errorMessage {
+			t.Errorf("expected %v, got %v", errorMessage, got)
+		}
+		if got := msg.GetLocale(); got == "" {
+			t.Error("missing locale")
+		}
+	}
+	if !found {
+		t.Error("missing LocalizedMessage detail")
+	}
+}
+
+func TestEncode_omits_localized_message_without_public_message(t *testing.T) {
+	err := apperror.Conflict(errorMessage)
+	grpcStatus := asGRPCError(t, grpcerror.Encode(err))
+
+	if details := grpcStatus.Details(); len(details) != 0 {
+		t.Errorf("unexpected details: %v", details)
+	}
+}
+
+func TestDecode_preserves_public_message(t *testing.T) {
+	for _, kind := range reversibleKinds() {
+		if kind == apperror.OK {
+			// nil errors do not have messages.
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, "internal details")
+			err = apperror.WithPublicMessage(err, errorMessage)
+			decodedErr := grpcerror.Decode(grpcerror.Encode(err))
+
+			msg := apperror.PublicMessage(decodedErr)
+			if msg != errorMessage {
+				t.Errorf(
+					"expected %q, got %q",
+					errorMessage,
+					msg,
+				)
+			}
+			if got := apperror.KindOf(decodedErr); got != kind {
+				t.Errorf("expected %v, got %v", kind, got)
+			}
+		})
+	}
+}
+
+func TestDecode_preserves_all_metadata(t *testing.T) {
+	err := apperror.WithViolations(
+		apperror.Validation("internal details"),
+		testViolations...,
+	)
+	err = apperror.WithReason(err, testReason)
+	err = apperror.WithRetryAfter(err, time.Second)
+	err = apperror.WithPublicMessage(err, errorMessage)
+	decodedErr := grpcerror.Decode(grpcerror.Encode(err))
+
+	if got := apperror.Violations(decodedErr); !slices.Equal(
+		got,
+		testViolations,
+	) {
+		t.Errorf("expected %v, got %v", testViolations, got)
+	}
+	if got, _ := apperror.ReasonOf(decodedErr); got != testReason {
+		t.Errorf("expected %v, got %v", testReason, got)
+	}
+	if got, _ := apperror.RetryAfter(decodedErr); got != time.Second {
+		t.Errorf("expected %v, got %v", time.Second, got)
+	}
+	if got := apperror.PublicMessage(decodedErr); got != errorMessage {
+		t.Errorf(`expected "%v", got "%v"`, errorMessage, got)
+	}
+	if !apperror.IsValidation(decodedErr) {
+		t.Error("unexpected kind:", apperror.KindOf(decodedErr))
+	}
+}
+
+var testViolations = []apperror.Violation{
+	{
+		Field:   "items[0].quantity",
+		Reason:  "NOT_POSITIVE",
+		Message: "quantity must be positive",
+	},
+	{
+		Field:   "customer",
+		Reason:  "REQUIRED",
+		Message: "customer is required",
+	},
+}
+
+var testReason = apperror.Reason{
+	Code:   "ORDER_ALREADY_SHIPPED",
+	Domain: "orders.example.com",
+}
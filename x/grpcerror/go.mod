@@ -3,14 +3,16 @@ module artk.dev/x/grpcerror
 go 1.22.0
 
 require (
-	artk.dev v0.3.0
+	artk.dev v0.5.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250102185135-69823020774d
 	google.golang.org/grpc v1.67.0
+	google.golang.org/protobuf v1.36.1
 )
 
 require (
+	golang.org/x/net v0.28.0 // indirect
 	golang.org/x/sys v0.24.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240814211410-ddb44dafa142 // indirect
-	google.golang.org/protobuf v1.34.2 // indirect
+	golang.org/x/text v0.17.0 // indirect
 )
 
 replace artk.dev => ../../
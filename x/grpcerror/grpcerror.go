@@ -12,18 +12,45 @@ import (
 )
 
 // Encode an application error into a gRPC error.
+//
+// Only the public message of the error is sent, never the internal error
+// chain. See apperror.PublicMessage.
+//
+// The metadata of the error is sent as standard google.rpc detail messages:
+//
+//   - Violations as BadRequest. See apperror.Violations.
+//   - Reasons as ErrorInfo. See apperror.ReasonOf.
+//   - Retry delays as RetryInfo. See apperror.RetryAfter.
+//   - Explicit public messages as LocalizedMessage, so that they survive
+//     for unknown errors. See apperror.WithPublicMessage.
 func Encode(err error) error {
+	return DefaultMapper.Encode(err)
+}
+
+// Encode is like the Encode function, but it uses the Mapper to map kinds
+// and codes.
+func (m Mapper) Encode(err error) error {
 	if err == nil {
 		return nil
 	}
 
 	kind := apperror.KindOf(err)
-	code := EncodeKind(kind)
-	return status.Error(code, err.Error())
+	code := m.EncodeKind(kind)
+	s := status.New(code, publicMessage(kind, err))
+	return encodeDetails(s, err).Err()
 }
 
 // Decode a gRPC error into an application error.
+//
+// The metadata sent by Encode is reconstructed from the detail messages.
+// Unrecognized detail messages are ignored.
 func Decode(err error) error {
+	return DefaultMapper.Decode(err)
+}
+
+// Decode is like the Decode function, but it uses the Mapper to map kinds
+// and codes.
+func (m Mapper) Decode(err error) error {
 	if err == nil {
 		return nil
 	}
@@ -34,58 +61,31 @@ func Decode(err error) error {
 		return apperror.Unknownf("cannot parse gRPC error: %w", err)
 	}
 
-	kind := DecodeKind(s.Code())
-	return apperror.New(kind, s.Message())
+	kind := m.DecodeKind(s.Code())
+	return decodeDetails(s, apperror.New(kind, s.Message()))
+}
+
+// publicMessage returns the message that can be safely sent to clients.
+// Unknown errors are redacted unless a public message was set explicitly.
+func publicMessage(kind apperror.Kind, err error) string {
+	msg := apperror.PublicMessage(err)
+	if msg == "" && kind == apperror.UnknownError {
+		return redactedMessage
+	}
+
+	return msg
 }
 
 // EncodeKind encodes an apperror.Kind into a gRPC codes.Code.
+// It uses DefaultMapper. See Mapper.EncodeKind.
 func EncodeKind(kind apperror.Kind) codes.Code {
-	switch kind {
-	case apperror.OK:
-		return codes.OK
-	case apperror.ValidationError:
-		return codes.InvalidArgument
-	case apperror.UnauthorizedError:
-		return codes.Unauthenticated
-	case apperror.ForbiddenError:
-		return codes.PermissionDenied
-	case apperror.NotFoundError:
-		return codes.NotFound
-	case apperror.ConflictError:
-		return codes.AlreadyExists
-	case apperror.PreconditionFailedError:
-		return codes.FailedPrecondition
-	case apperror.TooManyRequestsError:
-		return codes.Unavailable
-	case apperror.TimeoutError:
-		return codes.DeadlineExceeded
-	default:
-		return codes.Unknown
-	}
+	return DefaultMapper.EncodeKind(kind)
 }
 
 // DecodeKind decodes a codes.Code into an apperror.Kind.
+// It uses DefaultMapper. See Mapper.DecodeKind.
 func DecodeKind(code codes.Code) apperror.Kind {
-	switch code {
-	case codes.OK:
-		return apperror.OK
-	case codes.InvalidArgument:
-		return apperror.ValidationError
-	case codes.Unauthenticated:
-		return apperror.UnauthorizedError
-	case codes.PermissionDenied:
-		return apperror.ForbiddenError
-	case codes.NotFound:
-		return apperror.NotFoundError
-	case codes.AlreadyExists:
-		return apperror.ConflictError
-	case codes.FailedPrecondition:
-		return apperror.PreconditionFailedError
-	case codes.Unavailable:
-		return apperror.TooManyRequestsError
-	case codes.DeadlineExceeded:
-		return apperror.TimeoutError
-	default:
-		return apperror.UnknownError
-	}
+	return DefaultMapper.DecodeKind(code)
 }
+
+const redactedMessage = "unknown error"
@@ -4,6 +4,7 @@ import (
 	"artk.dev/apperror"
 	"artk.dev/x/grpcerror"
 	"errors"
+	"fmt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"testing"
@@ -28,6 +29,10 @@ func TestEncode_encodes_message_for_error_kinds(t *testing.T) {
 			// nil errors do not have messages.
 			continue
 		}
+		if kind == apperror.UnknownError {
+			// Special case: the message is redacted.
+			continue
+		}
 
 		t.Run(kind.String(), func(t *testing.T) {
 			originalErr := apperror.New(kind, errorMessage)
@@ -39,6 +44,35 @@ func TestEncode_encodes_message_for_error_kinds(t *testing.T) {
 	}
 }
 
+func TestEncode_redacts_the_message_of_unknown_errors(t *testing.T) {
+	encodedErr := grpcerror.Encode(errors.New("an unknown error"))
+	grpcStatus := asGRPCError(t, encodedErr)
+	assertMessageIs(t, grpcStatus, "unknown error")
+}
+
+func TestEncode_does_not_leak_wrap_context(t *testing.T) {
+	err := apperror.NotFound(errorMessage)
+	err = fmt.Errorf("SELECT * FROM orders WHERE tenant = 42: %w", err)
+	grpcStatus := asGRPCError(t, grpcerror.Encode(err))
+	assertMessageIs(t, grpcStatus, errorMessage)
+}
+
+func TestEncode_encodes_explicit_public_message(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		if kind == apperror.OK {
+			// nil errors do not have messages.
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, "internal details")
+			err = apperror.WithPublicMessage(err, errorMessage)
+			grpcStatus := asGRPCError(t, grpcerror.Encode(err))
+			assertMessageIs(t, grpcStatus, errorMessage)
+		})
+	}
+}
+
 func TestEncode_encodes_empty_message_for_OK(t *testing.T) {
 	encodedErr := grpcerror.Encode(nil)
 
@@ -55,7 +89,7 @@ func TestEncode_encodes_empty_message_for_OK(t *testing.T) {
 }
 
 func TestDecode_preserves_kind(t *testing.T) {
-	for _, kind := range apperror.KindValues() {
+	for _, kind := range reversibleKinds() {
 		t.Run(kind.String(), func(t *testing.T) {
 			originalErr := apperror.New(kind, errorMessage)
 			encodedErr := grpcerror.Encode(originalErr)
@@ -70,7 +104,7 @@ func TestDecode_preserves_kind(t *testing.T) {
 }
 
 func TestDecode_preserves_message_for_errors(t *testing.T) {
-	for _, kind := range apperror.KindValues() {
+	for _, kind := range reversibleKinds() {
 		if kind == apperror.OK {
 			// nil errors do not have messages.
 			continue
@@ -110,7 +144,7 @@ func TestDecode_return_unknown_error_on_failure(t *testing.T) {
 }
 
 func TestEncodeKind_encoding_is_reversible(t *testing.T) {
-	for _, kind := range apperror.KindValues() {
+	for _, kind := range reversibleKinds() {
 		t.Run(kind.String(), func(t *testing.T) {
 			code := grpcerror.EncodeKind(kind)
 			got := grpcerror.DecodeKind(code)
@@ -127,12 +161,9 @@ func TestEncodeKind_encoding_is_reversible(t *testing.T) {
 
 func TestDecodeKind_returns_unknown_for_codes_without_kind(t *testing.T) {
 	for _, code := range []codes.Code{
-		codes.Canceled,
 		codes.Unknown,
-		codes.ResourceExhausted,
 		codes.Aborted,
 		codes.OutOfRange,
-		codes.Unimplemented,
 		codes.Internal,
 		codes.DataLoss,
 	} {
@@ -145,6 +176,31 @@ func TestDecodeKind_returns_unknown_for_codes_without_kind(t *testing.T) {
 	}
 }
 
+func TestEncodeKind_gone_degrades_to_not_found(t *testing.T) {
+	code := grpcerror.EncodeKind(apperror.GoneError)
+	if expected := codes.NotFound; code != expected {
+		t.Errorf("expected %v, got %v", expected, code)
+	}
+
+	got := grpcerror.DecodeKind(code)
+	if expected := apperror.NotFoundError; got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+// reversibleKinds returns the kinds that have their own gRPC code.
+func reversibleKinds() []apperror.Kind {
+	kinds := make([]apperror.Kind, 0, len(apperror.KindValues()))
+	for _, kind := range apperror.KindValues() {
+		// gRPC lacks a code for GoneError.
+		if kind != apperror.GoneError {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	return kinds
+}
+
 func asGRPCError(t *testing.T, err error) *status.Status {
 	t.Helper()
 
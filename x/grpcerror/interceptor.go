@@ -0,0 +1,220 @@
+package grpcerror
+
+import (
+	"artk.dev/apperror"
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+)
+
+// UnaryServerInterceptor encodes the errors returned by unary RPC handlers
+// with Encode, so that handlers can return application errors as is.
+//
+// Errors that are already gRPC errors, e.g., those returned by
+// UnaryServerRecoveryInterceptor, are returned unchanged.
+func UnaryServerInterceptor(
+	optionsFn ...func(options *interceptorOptions),
+) grpc.UnaryServerInterceptor {
+	options := newInterceptorOptions(optionsFn)
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		response, err := handler(ctx, req)
+		if err != nil {
+			return nil, options.encode(ctx, info.FullMethod, err)
+		}
+
+		return response, nil
+	}
+}
+
+// StreamServerInterceptor encodes the errors returned by streaming RPC
+// handlers with Encode, so that handlers can return application errors as
+// is.
+//
+// Errors that are already gRPC errors, e.g., those returned by
+// StreamServerRecoveryInterceptor, are returned unchanged.
+func StreamServerInterceptor(
+	optionsFn ...func(options *interceptorOptions),
+) grpc.StreamServerInterceptor {
+	options := newInterceptorOptions(optionsFn)
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
+		}
+
+		return options.encode(ss.Context(), info.FullMethod, err)
+	}
+}
+
+// UnaryClientInterceptor decodes the errors of unary RPCs with Decode, so
+// that callers receive application errors.
+func UnaryClientInterceptor(
+	optionsFn ...func(options *interceptorOptions),
+) grpc.UnaryClientInterceptor {
+	options := newInterceptorOptions(optionsFn)
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		return options.decode(ctx, method, err)
+	}
+}
+
+// StreamClientInterceptor decodes the errors of streaming RPCs with Decode,
+// so that callers receive application errors. This includes the errors of
+// the methods of the grpc.ClientStream, except for io.EOF, which marks the
+// end of the stream.
+func StreamClientInterceptor(
+	optionsFn ...func(options *interceptorOptions),
+) grpc.StreamClientInterceptor {
+	options := newInterceptorOptions(optionsFn)
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, options.decode(ctx, method, err)
+		}
+
+		return &clientStream{
+			ClientStream: cs,
+			method:       method,
+			options:      options,
+		}, nil
+	}
+}
+
+// WithLogger sets the logger used by an interceptor to report unknown
+// errors. By default, they are not reported.
+func WithLogger(logger *slog.Logger) func(options *interceptorOptions) {
+	return func(options *interceptorOptions) {
+		options.logger = logger
+	}
+}
+
+// WithMapper sets the Mapper used by an interceptor to encode or decode
+// errors. By default, it is DefaultMapper.
+func WithMapper(m Mapper) func(options *interceptorOptions) {
+	return func(options *interceptorOptions) {
+		options.mapper = m
+	}
+}
+
+type interceptorOptions struct {
+	logger *slog.Logger
+	mapper Mapper
+}
+
+func newInterceptorOptions(
+	optionsFn []func(options *interceptorOptions),
+) *interceptorOptions {
+	options := &interceptorOptions{mapper: DefaultMapper}
+	for _, fn := range optionsFn {
+		fn(options)
+	}
+
+	return options
+}
+
+func (o *interceptorOptions) encode(
+	ctx context.Context,
+	method string,
+	err error,
+) error {
+	if _, ok := status.FromError(err); ok {
+		// Already encoded, possibly wrapped.
+		return err
+	}
+
+	if apperror.IsUnknown(err) {
+		o.log(ctx, method, err, "gRPC handler failed")
+	}
+
+	return o.mapper.Encode(err)
+}
+
+func (o *interceptorOptions) decode(
+	ctx context.Context,
+	method string,
+	err error,
+) error {
+	if err == nil {
+		return nil
+	}
+
+	decoded := o.mapper.Decode(err)
+	if apperror.IsUnknown(decoded) {
+		o.log(ctx, method, decoded, "gRPC call failed")
+	}
+
+	return decoded
+}
+
+func (o *interceptorOptions) log(
+	ctx context.Context,
+	method string,
+	err error,
+	msg string,
+) {
+	if o.logger == nil {
+		return
+	}
+
+	o.logger.ErrorContext(
+		ctx,
+		msg,
+		slog.String("method", method),
+		slog.Attr{Key: "error", Value: apperror.LogValue(err)},
+	)
+}
+
+// clientStream decodes the errors of a grpc.ClientStream.
+type clientStream struct {
+	grpc.ClientStream
+	method  string
+	options *interceptorOptions
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	return s.decode(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	return s.decode(s.ClientStream.RecvMsg(m))
+}
+
+func (s *clientStream) CloseSend() error {
+	return s.decode(s.ClientStream.CloseSend())
+}
+
+func (s *clientStream) decode(err error) error {
+	if errors.Is(err, io.EOF) {
+		// End of the stream, not an error.
+		return err
+	}
+
+	return s.options.decode(s.Context(), s.method, err)
+}
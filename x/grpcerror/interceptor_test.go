@@ -0,0 +1,359 @@
+package grpcerror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/x/grpcerror"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestInterceptors_round_trip_unary_errors(t *testing.T) {
+	for _, kind := range reversibleKinds() {
+		if kind == apperror.OK || kind == apperror.UnknownError {
+			// Unknown errors are redacted. See the test below.
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, errorMessage)
+			err = apperror.WithReason(err, testReason)
+			client := startServer(t, healthServer{err: err})
+
+			_, err = client.Check(
+				context.Background(),
+				&grpc_health_v1.HealthCheckRequest{},
+			)
+
+			assertDecodedError(t, err, kind)
+		})
+	}
+}
+
+func TestInterceptors_round_trip_streaming_errors(t *testing.T) {
+	for _, kind := range reversibleKinds() {
+		if kind == apperror.OK || kind == apperror.UnknownError {
+			// Unknown errors are redacted. See the test below.
+			continue
+		}
+
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, errorMessage)
+			err = apperror.WithReason(err, testReason)
+			client := startServer(t, healthServer{err: err})
+
+			stream, err := client.Watch(
+				context.Background(),
+				&grpc_health_v1.HealthCheckRequest{},
+			)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			// The handler sends a message before failing.
+			if _, err := stream.Recv(); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			_, err = stream.Recv()
+
+			assertDecodedError(t, err, kind)
+		})
+	}
+}
+
+func TestInterceptors_preserve_end_of_stream(t *testing.T) {
+	client := startServer(t, healthServer{})
+
+	stream, err := client.Watch(
+		context.Background(),
+		&grpc_health_v1.HealthCheckRequest{},
+	)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("expected %v, got %v", io.EOF, err)
+	}
+}
+
+func TestInterceptors_succeed_without_errors(t *testing.T) {
+	client := startServer(t, healthServer{})
+
+	response, err := client.Check(
+		context.Background(),
+		&grpc_health_v1.HealthCheckRequest{},
+	)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := grpc_health_v1.HealthCheckResponse_SERVING
+	if got := response.GetStatus(); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestInterceptors_redact_unknown_errors(t *testing.T) {
+	var serverLog, clientLog bytes.Buffer
+	client := startServerWith(
+		t,
+		healthServer{err: errors.New("secret details")},
+		loggingInterceptors(&serverLog, &clientLog),
+	)
+
+	_, err := client.Check(
+		context.Background(),
+		&grpc_health_v1.HealthCheckRequest{},
+	)
+
+	if !apperror.IsUnknown(err) {
+		t.Error("unexpected kind:", apperror.KindOf(err))
+	}
+	if strings.Contains(err.Error(), "secret details") {
+		t.Error("leaked message:", err)
+	}
+	if got := serverLog.String(); !strings.Contains(got, "secret details") {
+		t.Error("unknown error not logged by the server:", got)
+	}
+	if got := clientLog.String(); !strings.Contains(got, "/Check") {
+		t.Error("unknown error not logged by the client:", got)
+	}
+}
+
+func TestInterceptors_do_not_log_known_errors(t *testing.T) {
+	var serverLog, clientLog bytes.Buffer
+	client := startServerWith(
+		t,
+		healthServer{err: apperror.NotFound(errorMessage)},
+		loggingInterceptors(&serverLog, &clientLog),
+	)
+
+	_, _ = client.Check(
+		context.Background(),
+		&grpc_health_v1.HealthCheckRequest{},
+	)
+
+	if got := serverLog.String(); got != "" {
+		t.Error("unexpected server log:", got)
+	}
+	if got := clientLog.String(); got != "" {
+		t.Error("unexpected client log:", got)
+	}
+}
+
+func TestUnaryServerInterceptor_passes_gRPC_errors_through(t *testing.T) {
+	interceptor := grpcerror.UnaryServerInterceptor()
+
+	expected := status.Error(codes.DataLoss, errorMessage)
+	_, err := interceptor(
+		context.TODO(),
+		nil,
+		&grpc.UnaryServerInfo{},
+		func(context.Context, any) (any, error) {
+			return nil, expected
+		},
+	)
+
+	if err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestUnaryServerInterceptor_passes_wrapped_gRPC_errors_through(
+	t *testing.T,
+) {
+	interceptor := grpcerror.UnaryServerInterceptor()
+
+	expected := fmt.Errorf(
+		"wrapped: %w",
+		status.Error(codes.DataLoss, errorMessage),
+	)
+	_, err := interceptor(
+		context.TODO(),
+		nil,
+		&grpc.UnaryServerInfo{},
+		func(context.Context, any) (any, error) {
+			return nil, expected
+		},
+	)
+
+	if err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestInterceptors_support_custom_mappers(t *testing.T) {
+	encoding := grpcerror.DefaultMapper.EncodingTable()
+	encoding[apperror.GoneError] = codes.FailedPrecondition
+	decoding := grpcerror.DefaultMapper.DecodingTable()
+	m, err := grpcerror.NewMapper(encoding, decoding)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	opt := grpcerror.WithMapper(m)
+	client := startServerWith(
+		t,
+		healthServer{err: apperror.Gone(errorMessage)},
+		interceptors{
+			unaryServer:  grpcerror.UnaryServerInterceptor(opt),
+			streamServer: grpcerror.StreamServerInterceptor(opt),
+			unaryClient:  grpcerror.UnaryClientInterceptor(opt),
+			streamClient: grpcerror.StreamClientInterceptor(opt),
+		},
+	)
+
+	_, err = client.Check(
+		context.Background(),
+		&grpc_health_v1.HealthCheckRequest{},
+	)
+
+	expected := m.DecodeKind(codes.FailedPrecondition)
+	if got := apperror.KindOf(err); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+// interceptors installed on both ends of the connection.
+type interceptors struct {
+	unaryServer  grpc.UnaryServerInterceptor
+	streamServer grpc.StreamServerInterceptor
+	unaryClient  grpc.UnaryClientInterceptor
+	streamClient grpc.StreamClientInterceptor
+}
+
+func loggingInterceptors(serverLog, clientLog io.Writer) interceptors {
+	serverLogger := grpcerror.WithLogger(newTestLogger(serverLog))
+	clientLogger := grpcerror.WithLogger(newTestLogger(clientLog))
+	return interceptors{
+		unaryServer:  grpcerror.UnaryServerInterceptor(serverLogger),
+		streamServer: grpcerror.StreamServerInterceptor(serverLogger),
+		unaryClient:  grpcerror.UnaryClientInterceptor(clientLogger),
+		streamClient: grpcerror.StreamClientInterceptor(clientLogger),
+	}
+}
+
+// healthServer fails with err, if not nil, after sending a response.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	err error
+}
+
+func (s healthServer) Check(
+	context.Context,
+	*grpc_health_v1.HealthCheckRequest,
+) (*grpc_health_v1.HealthCheckResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{
+		Status: grpc_health_v1.HealthCheckResponse_SERVING,
+	}, nil
+}
+
+func (s healthServer) Watch(
+	_ *grpc_health_v1.HealthCheckRequest,
+	stream grpc_health_v1.Health_WatchServer,
+) error {
+	err := stream.Send(&grpc_health_v1.HealthCheckResponse{
+		Status: grpc_health_v1.HealthCheckResponse_SERVING,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.err
+}
+
+func startServer(
+	t *testing.T,
+	server grpc_health_v1.HealthServer,
+) grpc_health_v1.HealthClient {
+	t.Helper()
+
+	return startServerWith(t, server, interceptors{
+		unaryServer:  grpcerror.UnaryServerInterceptor(),
+		streamServer: grpcerror.StreamServerInterceptor(),
+		unaryClient:  grpcerror.UnaryClientInterceptor(),
+		streamClient: grpcerror.StreamClientInterceptor(),
+	})
+}
+
+func startServerWith(
+	t *testing.T,
+	server grpc_health_v1.HealthServer,
+	i interceptors,
+) grpc_health_v1.HealthClient {
+	t.Helper()
+
+	listener := bufconn.Listen(bufferSize)
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(i.unaryServer),
+		grpc.StreamInterceptor(i.streamServer),
+	)
+	grpc_health_v1.RegisterHealthServer(s, server)
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(
+			func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			},
+		),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(i.unaryClient),
+		grpc.WithStreamInterceptor(i.streamClient),
+	)
+	if err != nil {
+		t.Fatal("cannot connect:", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func assertDecodedError(t *testing.T, err error, kind apperror.Kind) {
+	t.Helper()
+
+	if _, ok := status.FromError(err); ok {
+		t.Fatal("expected an application error, got", err)
+	}
+	if got := apperror.KindOf(err); got != kind {
+		t.Errorf("expected %v, got %v", kind, got)
+	}
+	if got := apperror.PublicMessage(err); got != errorMessage {
+		t.Errorf(`expected "%v", got "%v"`, errorMessage, got)
+	}
+	if got, _ := apperror.ReasonOf(err); got != testReason {
+		t.Errorf("expected %v, got %v", testReason, got)
+	}
+}
+
+func newTestLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, nil))
+}
+
+const bufferSize = 1 << 20
@@ -0,0 +1,200 @@
+package grpcerror
+
+import (
+	"artk.dev/apperror"
+	"google.golang.org/grpc/codes"
+	"maps"
+)
+
+// Mapper maps kinds to gRPC codes and back. The methods of a Mapper are
+// variants of the functions in this package that use its mapping instead of
+// the default one.
+//
+// The zero value uses the same mapping as DefaultMapper.
+type Mapper struct {
+	encoding map[apperror.Kind]codes.Code
+	decoding map[codes.Code]apperror.Kind
+}
+
+// DefaultMapper is used by EncodeKind, DecodeKind, and all the functions that
+// depend on them.
+var DefaultMapper = Mapper{
+	encoding: defaultEncoding,
+	decoding: defaultDecoding,
+}
+
+// NewMapper creates a Mapper from an encoding table, which maps every kind
+// to a code, and a decoding table, which maps codes back to kinds.
+// Use the tables of DefaultMapper as a starting point.
+//
+// The tables are validated for round-trip consistency:
+//
+//   - The encoding table must contain every value of apperror.KindValues.
+//   - OK must be encoded as codes.OK, and no other kind can be.
+//   - The decoding table cannot contain codes.OK or invalid codes, and codes
+//     cannot be decoded as OK or an invalid kind.
+//   - Decoding the code of a kind must result in the same kind or, if
+//     several kinds share the code, in one of them.
+//
+// Codes that are not in the decoding table are decoded as
+// apperror.UnknownError.
+func NewMapper(
+	encoding map[apperror.Kind]codes.Code,
+	decoding map[codes.Code]apperror.Kind,
+) (Mapper, error) {
+	m := Mapper{
+		encoding: make(map[apperror.Kind]codes.Code, len(encoding)),
+		decoding: make(map[codes.Code]apperror.Kind, len(decoding)),
+	}
+	maps.Copy(m.encoding, encoding)
+	maps.Copy(m.decoding, decoding)
+
+	if err := m.validate(); err != nil {
+		return Mapper{}, err
+	}
+
+	return m, nil
+}
+
+// EncodingTable returns a copy of the table used to encode kinds.
+func (m Mapper) EncodingTable() map[apperror.Kind]codes.Code {
+	return maps.Clone(m.encodingTable())
+}
+
+// DecodingTable returns a copy of the table used to decode codes.
+func (m Mapper) DecodingTable() map[codes.Code]apperror.Kind {
+	return maps.Clone(m.decodingTable())
+}
+
+// EncodeKind encodes an apperror.Kind into a gRPC codes.Code.
+// Invalid kinds are encoded like apperror.UnknownError.
+func (m Mapper) EncodeKind(kind apperror.Kind) codes.Code {
+	code, ok := m.encodingTable()[kind]
+	if !ok {
+		return m.encodingTable()[apperror.UnknownError]
+	}
+
+	return code
+}
+
+// DecodeKind decodes a codes.Code into an apperror.Kind.
+func (m Mapper) DecodeKind(code codes.Code) apperror.Kind {
+	if code == codes.OK {
+		return apperror.OK
+	}
+
+	kind, ok := m.decodingTable()[code]
+	if !ok {
+		return apperror.UnknownError
+	}
+
+	return kind
+}
+
+func (m Mapper) encodingTable() map[apperror.Kind]codes.Code {
+	if m.encoding == nil {
+		return defaultEncoding
+	}
+
+	return m.encoding
+}
+
+func (m Mapper) decodingTable() map[codes.Code]apperror.Kind {
+	if m.decoding == nil {
+		return defaultDecoding
+	}
+
+	return m.decoding
+}
+
+func (m Mapper) validate() error {
+	for code, kind := range m.decoding {
+		if code == codes.OK || code > maxCode {
+			return apperror.Validationf(
+				"cannot decode code %v",
+				code,
+			)
+		}
+
+		_, err := kind.MarshalText()
+		if err != nil || kind == apperror.OK {
+			return apperror.Validationf(
+				"cannot decode code %v as %v",
+				code,
+				kind,
+			)
+		}
+	}
+
+	for _, kind := range apperror.KindValues() {
+		if err := m.validateKind(kind); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m Mapper) validateKind(kind apperror.Kind) error {
+	code, ok := m.encoding[kind]
+	switch {
+	case !ok:
+		return apperror.Validationf("missing code for %v", kind)
+	case (kind == apperror.OK) != (code == codes.OK), code > maxCode:
+		return apperror.Validationf(
+			"cannot encode %v as code %v",
+			kind,
+			code,
+		)
+	}
+
+	decoded := m.DecodeKind(code)
+	if m.encoding[decoded] != code {
+		return apperror.Validationf(
+			"%v is encoded as code %v, but decoded as %v",
+			kind,
+			code,
+			decoded,
+		)
+	}
+
+	return nil
+}
+
+// maxCode is the highest code defined by gRPC.
+const maxCode = codes.Unauthenticated
+
+var defaultEncoding = map[apperror.Kind]codes.Code{
+	apperror.OK:                      codes.OK,
+	apperror.UnknownError:            codes.Unknown,
+	apperror.ValidationError:         codes.InvalidArgument,
+	apperror.UnauthorizedError:       codes.Unauthenticated,
+	apperror.ForbiddenError:          codes.PermissionDenied,
+	apperror.NotFoundError:           codes.NotFound,
+	apperror.ConflictError:           codes.AlreadyExists,
+	apperror.PreconditionFailedError: codes.FailedPrecondition,
+	apperror.TooManyRequestsError:    codes.ResourceExhausted,
+	apperror.TimeoutError:            codes.DeadlineExceeded,
+	apperror.UnimplementedError:      codes.Unimplemented,
+	apperror.UnavailableError:        codes.Unavailable,
+	apperror.CanceledError:           codes.Canceled,
+	// gRPC lacks a code for resources that no longer exist.
+	// NotFound is the closest one, although it cannot be
+	// decoded back into GoneError.
+	apperror.GoneError: codes.NotFound,
+}
+
+var defaultDecoding = map[codes.Code]apperror.Kind{
+	codes.Unknown:            apperror.UnknownError,
+	codes.InvalidArgument:    apperror.ValidationError,
+	codes.Unauthenticated:    apperror.UnauthorizedError,
+	codes.PermissionDenied:   apperror.ForbiddenError,
+	codes.NotFound:           apperror.NotFoundError,
+	codes.AlreadyExists:      apperror.ConflictError,
+	codes.FailedPrecondition: apperror.PreconditionFailedError,
+	codes.ResourceExhausted:  apperror.TooManyRequestsError,
+	codes.DeadlineExceeded:   apperror.TimeoutError,
+	codes.Unimplemented:      apperror.UnimplementedError,
+	codes.Unavailable:        apperror.UnavailableError,
+	codes.Canceled:           apperror.CanceledError,
+}
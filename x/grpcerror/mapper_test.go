@@ -0,0 +1,144 @@
+package grpcerror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/assume"
+	"artk.dev/x/grpcerror"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func ExampleNewMapper() {
+	encoding := grpcerror.DefaultMapper.EncodingTable()
+	encoding[apperror.TooManyRequestsError] = codes.Unavailable
+
+	decoding := grpcerror.DefaultMapper.DecodingTable()
+	delete(decoding, codes.ResourceExhausted)
+
+	m, err := grpcerror.NewMapper(encoding, decoding)
+	if err != nil {
+		fmt.Println("Unexpected error:", err)
+		return
+	}
+
+	err = m.Encode(apperror.TooManyRequests("slow down"))
+	fmt.Println(status.Code(err))
+
+	// Output: Unavailable
+}
+
+func TestMapper_zero_value_is_the_default(t *testing.T) {
+	var m grpcerror.Mapper
+	for _, kind := range apperror.KindValues() {
+		expected := grpcerror.EncodeKind(kind)
+		if got := m.EncodeKind(kind); got != expected {
+			t.Errorf("%v: expected %v, got %v", kind, expected, got)
+		}
+	}
+
+	for code := range codes.Code(20) {
+		expected := grpcerror.DecodeKind(code)
+		if got := m.DecodeKind(code); got != expected {
+			t.Errorf("%v: expected %v, got %v", code, expected, got)
+		}
+	}
+}
+
+func TestMapper_default_tables_are_valid(t *testing.T) {
+	_, err := grpcerror.NewMapper(
+		grpcerror.DefaultMapper.EncodingTable(),
+		grpcerror.DefaultMapper.DecodingTable(),
+	)
+	if err != nil {
+		t.Error("unexpected error:", err)
+	}
+}
+
+func TestNewMapper_rejects_inconsistent_encoding(t *testing.T) {
+	for name, modify := range map[string]func(e kindCodes){
+		"missing kind": func(e kindCodes) {
+			delete(e, apperror.GoneError)
+		},
+		"OK is an error": func(e kindCodes) {
+			e[apperror.OK] = codes.Internal
+		},
+		"error is OK": func(e kindCodes) {
+			e[apperror.GoneError] = codes.OK
+		},
+		"invalid code": func(e kindCodes) {
+			e[apperror.GoneError] = 100
+		},
+		"not decodable": func(e kindCodes) {
+			e[apperror.GoneError] = codes.DataLoss
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			encoding := grpcerror.DefaultMapper.EncodingTable()
+			modify(encoding)
+
+			_, err := grpcerror.NewMapper(
+				encoding,
+				grpcerror.DefaultMapper.DecodingTable(),
+			)
+			if !apperror.IsValidation(err) {
+				t.Error("expected validation error, got:", err)
+			}
+		})
+	}
+}
+
+func TestNewMapper_rejects_inconsistent_decoding(t *testing.T) {
+	for name, modify := range map[string]func(d codeKinds){
+		"OK code": func(d codeKinds) {
+			d[codes.OK] = apperror.UnknownError
+		},
+		"invalid code": func(d codeKinds) {
+			d[100] = apperror.UnknownError
+		},
+		"OK kind": func(d codeKinds) {
+			d[codes.DataLoss] = apperror.OK
+		},
+		"invalid kind": func(d codeKinds) {
+			d[codes.DataLoss] = -1
+		},
+		"not reversible": func(d codeKinds) {
+			d[codes.AlreadyExists] = apperror.NotFoundError
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			decoding := grpcerror.DefaultMapper.DecodingTable()
+			modify(decoding)
+
+			_, err := grpcerror.NewMapper(
+				grpcerror.DefaultMapper.EncodingTable(),
+				decoding,
+			)
+			if !apperror.IsValidation(err) {
+				t.Error("expected validation error, got:", err)
+			}
+		})
+	}
+}
+
+func TestMapper_Encode_and_Decode_use_the_mapping(t *testing.T) {
+	encoding := grpcerror.DefaultMapper.EncodingTable()
+	encoding[apperror.GoneError] = codes.DataLoss
+	decoding := grpcerror.DefaultMapper.DecodingTable()
+	decoding[codes.DataLoss] = apperror.GoneError
+	m, err := grpcerror.NewMapper(encoding, decoding)
+	assume.Success(err)
+
+	encodedErr := m.Encode(apperror.Gone(errorMessage))
+	assertCodeIs(t, asGRPCError(t, encodedErr), codes.DataLoss)
+
+	if err := m.Decode(encodedErr); !apperror.IsGone(err) {
+		t.Error("expected gone error, got:", err)
+	}
+}
+
+type (
+	kindCodes = map[apperror.Kind]codes.Code
+	codeKinds = map[codes.Code]apperror.Kind
+)
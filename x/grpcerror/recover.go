@@ -0,0 +1,77 @@
+package grpcerror
+
+import (
+	"artk.dev/apperror"
+	"context"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerRecoveryInterceptor recovers panics in unary RPC handlers and
+// returns them as unknown errors encoded with Encode.
+// See apperror.FromPanic.
+//
+// The optional report function is called with every recovered panic, e.g.,
+// to log it. The error carries the panic value and the stack trace.
+func UnaryServerRecoveryInterceptor(
+	report func(ctx context.Context, err error),
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (response any, err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = recovered(
+					ctx,
+					report,
+					apperror.FromPanic(v),
+				)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerRecoveryInterceptor recovers panics in streaming RPC handlers
+// and returns them as unknown errors encoded with Encode.
+// See apperror.FromPanic.
+//
+// The optional report function is called with every recovered panic, e.g.,
+// to log it. The error carries the panic value and the stack trace.
+func StreamServerRecoveryInterceptor(
+	report func(ctx context.Context, err error),
+) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = recovered(
+					ss.Context(),
+					report,
+					apperror.FromPanic(v),
+				)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func recovered(
+	ctx context.Context,
+	report func(ctx context.Context, err error),
+	err error,
+) error {
+	if report != nil {
+		report(ctx, err)
+	}
+
+	return Encode(err)
+}
@@ -0,0 +1,160 @@
+package grpcerror_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/x/grpcerror"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"testing"
+)
+
+func TestUnaryServerRecoveryInterceptor_recovers_panics(t *testing.T) {
+	var reported error
+	interceptor := grpcerror.UnaryServerRecoveryInterceptor(
+		func(_ context.Context, err error) {
+			reported = err
+		},
+	)
+
+	response, err := interceptor(
+		context.TODO(),
+		nil,
+		&grpc.UnaryServerInfo{},
+		func(context.Context, any) (any, error) {
+			panic("secret details")
+		},
+	)
+
+	if response != nil {
+		t.Error("unexpected response:", response)
+	}
+	grpcStatus := asGRPCError(t, err)
+	assertCodeIs(t, grpcStatus, codes.Unknown)
+	assertMessageIs(t, grpcStatus, "unknown error")
+	if _, ok := apperror.PanicValue(reported); !ok {
+		t.Error("unexpected reported error:", reported)
+	}
+}
+
+func TestUnaryServerRecoveryInterceptor_passes_through(t *testing.T) {
+	interceptor := grpcerror.UnaryServerRecoveryInterceptor(nil)
+
+	expected := apperror.NotFound(errorMessage)
+	response, err := interceptor(
+		context.TODO(),
+		nil,
+		&grpc.UnaryServerInfo{},
+		func(context.Context, any) (any, error) {
+			return "response", expected
+		},
+	)
+
+	if response != "response" {
+		t.Error("unexpected response:", response)
+	}
+	if err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestUnaryServerRecoveryInterceptor_ignores_returned_panics(t *testing.T) {
+	interceptor := grpcerror.UnaryServerRecoveryInterceptor(
+		func(_ context.Context, err error) {
+			t.Error("unexpected report:", err)
+		},
+	)
+
+	expected := apperror.Catch(func() error {
+		panic("handled by the handler")
+	})
+	_, err := interceptor(
+		context.TODO(),
+		nil,
+		&grpc.UnaryServerInfo{},
+		func(context.Context, any) (any, error) {
+			return nil, expected
+		},
+	)
+
+	if err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestStreamServerRecoveryInterceptor_recovers_panics(t *testing.T) {
+	var reported error
+	interceptor := grpcerror.StreamServerRecoveryInterceptor(
+		func(_ context.Context, err error) {
+			reported = err
+		},
+	)
+
+	err := interceptor(
+		nil,
+		serverStream{ctx: context.TODO()},
+		&grpc.StreamServerInfo{},
+		func(any, grpc.ServerStream) error {
+			panic("secret details")
+		},
+	)
+
+	grpcStatus := asGRPCError(t, err)
+	assertCodeIs(t, grpcStatus, codes.Unknown)
+	assertMessageIs(t, grpcStatus, "unknown error")
+	if _, ok := apperror.PanicValue(reported); !ok {
+		t.Error("unexpected reported error:", reported)
+	}
+}
+
+func TestStreamServerRecoveryInterceptor_passes_through(t *testing.T) {
+	interceptor := grpcerror.StreamServerRecoveryInterceptor(nil)
+
+	expected := apperror.NotFound(errorMessage)
+	err := interceptor(
+		nil,
+		serverStream{ctx: context.TODO()},
+		&grpc.StreamServerInfo{},
+		func(any, grpc.ServerStream) error {
+			return expected
+		},
+	)
+
+	if err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestStreamServerRecoveryInterceptor_ignores_returned_panics(t *testing.T) {
+	interceptor := grpcerror.StreamServerRecoveryInterceptor(
+		func(_ context.Context, err error) {
+			t.Error("unexpected report:", err)
+		},
+	)
+
+	expected := apperror.Catch(func() error {
+		panic("handled by the handler")
+	})
+	err := interceptor(
+		nil,
+		serverStream{ctx: context.TODO()},
+		&grpc.StreamServerInfo{},
+		func(any, grpc.ServerStream) error {
+			return expected
+		},
+	)
+
+	if err != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+// serverStream is a minimal grpc.ServerStream for tests.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s serverStream) Context() context.Context {
+	return s.ctx
+}
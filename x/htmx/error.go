@@ -0,0 +1,181 @@
+package htmx
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"context"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrorView contains the information about an error that can be shown to
+// users. It never contains the internal error chain.
+type ErrorView struct {
+	Kind       apperror.Kind
+	Status     int
+	Message    string
+	Violations []apperror.Violation
+}
+
+// ErrorComponent creates the Template that renders an error.
+type ErrorComponent func(view ErrorView) Template
+
+// ErrorPage creates the Template that renders a full page around an error
+// component, for requests that are not partial updates.
+type ErrorPage func(view ErrorView, component Template) Template
+
+// ErrorRenderer renders errors as HTML, choosing the component per kind.
+//
+// Partial updates are retargeted to the error region, if any, so that errors
+// do not replace the content that the request was meant to update. Other
+// requests, e.g., navigation, get a full error page. See RenderingModeFor.
+//
+// Note that htmx does not swap error responses by default. Enable it in the
+// responseHandling configuration of htmx, or with an extension.
+type ErrorRenderer struct {
+	// Components maps kinds to the component that renders them.
+	Components map[apperror.Kind]ErrorComponent
+
+	// DefaultComponent renders the kinds without a component. If nil, a
+	// div element with class "error" and the ARIA role "alert" is used,
+	// like httperror.EncodeToHTML does.
+	DefaultComponent ErrorComponent
+
+	// Page renders full pages. If nil, a minimal page is used.
+	Page ErrorPage
+
+	// Target is the CSS selector of the error region, e.g., "#errors".
+	// If empty, partial updates are not retargeted.
+	Target string
+
+	// Swap is the swap strategy for the error region. If empty, the
+	// content of the error region is replaced, i.e., "innerHTML".
+	Swap string
+
+	// Mapper maps kinds to status codes. The zero value uses the same
+	// mapping as httperror.DefaultMapper.
+	Mapper httperror.Mapper
+
+	// OnError is synchronously called when rendering fails.
+	OnError func(error)
+}
+
+// Encode writes an error into an HTTP response. It is an httperror.Encoder,
+// so it can be registered for text/html with httperror.RegisterEncoder.
+//
+// Unknown errors are redacted unless a public message was explicitly
+// provided. See apperror.PublicMessage.
+// No further writes to the ResponseWriter w should happen after this method.
+func (r ErrorRenderer) Encode(
+	w http.ResponseWriter,
+	req *http.Request,
+	err error,
+) {
+	status := r.Mapper.EncodeHeader(w.Header(), err)
+	kind := apperror.KindOf(err)
+	if kind == apperror.OK {
+		w.WriteHeader(status)
+		return
+	}
+
+	view := ErrorView{
+		Kind:       kind,
+		Status:     status,
+		Message:    r.Mapper.PublicMessage(err),
+		Violations: apperror.Violations(err),
+	}
+	component := r.component(view)
+
+	header := w.Header()
+	setHeaders(header)
+	var content Template
+	switch RenderingModeFor(req) {
+	case PartialUpdate:
+		content = component
+		if r.Target != "" {
+			header.Set(hxRetargetHeader, r.Target)
+			header.Set(hxReswapHeader, r.swap())
+		}
+	default:
+		content = r.page(view, component)
+	}
+
+	w.WriteHeader(status)
+	Renderer{OnError: r.OnError}.render(req.Context(), w, content)
+}
+
+func (r ErrorRenderer) component(view ErrorView) Template {
+	if component, ok := r.Components[view.Kind]; ok {
+		return component(view)
+	}
+	if r.DefaultComponent != nil {
+		return r.DefaultComponent(view)
+	}
+
+	return defaultComponent(view)
+}
+
+func (r ErrorRenderer) page(view ErrorView, component Template) Template {
+	if r.Page != nil {
+		return r.Page(view, component)
+	}
+
+	return pageTemplate{
+		title:     httperror.StatusText(view.Status),
+		component: component,
+	}
+}
+
+func (r ErrorRenderer) swap() string {
+	if r.Swap == "" {
+		return "innerHTML"
+	}
+
+	return r.Swap
+}
+
+// defaultComponent renders the same fragment as httperror.EncodeToHTML.
+type defaultComponent ErrorView
+
+func (c defaultComponent) Render(_ context.Context, w io.Writer) error {
+	return httperror.WriteHTML(w, c.Kind, c.Message, c.Violations)
+}
+
+// pageTemplate renders a component inside the default page.
+type pageTemplate struct {
+	title     string
+	component Template
+}
+
+func (t pageTemplate) Render(ctx context.Context, w io.Writer) error {
+	var body strings.Builder
+	if err := t.component.Render(ctx, &body); err != nil {
+		return err
+	}
+
+	return defaultPage.Execute(w, defaultPageData{
+		Title: t.title,
+		// Components are responsible for escaping their own content.
+		Body: template.HTML(body.String()), //nolint:gosec
+	})
+}
+
+type defaultPageData struct {
+	Title string
+	Body  template.HTML
+}
+
+var defaultPage = template.Must(template.New("page").Parse(
+	`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+{{.Body}}
+</body>
+</html>
+`))
@@ -0,0 +1,340 @@
+package htmx_test
+
+import (
+	"artk.dev/apperror"
+	"artk.dev/httperror"
+	"artk.dev/x/htmx"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func ExampleErrorRenderer_Encode() {
+	renderer := htmx.ErrorRenderer{Target: "#errors"}
+
+	r := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	r.Header.Set("Hx-Request", "true")
+	w := httptest.NewRecorder()
+	renderer.Encode(w, r, apperror.Conflict("the order already exists"))
+
+	fmt.Println(w.Code)
+	fmt.Println(w.Header().Get("Hx-Retarget"))
+	fmt.Println(w.Header().Get("Hx-Reswap"))
+	fmt.Print(w.Body.String())
+
+	// Output:
+	// 409
+	// #errors
+	// innerHTML
+	// <div class="error" role="alert" data-kind="conflict">
+	// <p>the order already exists</p>
+	// </div>
+}
+
+func TestErrorRenderer_Encode_encodes_kind_into_status_code(t *testing.T) {
+	for _, kind := range apperror.KindValues() {
+		t.Run(kind.String(), func(t *testing.T) {
+			err := apperror.New(kind, errorMessage)
+			w := httptest.NewRecorder()
+			htmx.ErrorRenderer{}.Encode(w, partialRequest(), err)
+
+			expected := httperror.EncodeKind(kind)
+			if got := w.Code; got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+}
+
+func TestErrorRenderer_Encode_adds_expected_headers(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := apperror.Conflict(errorMessage)
+	htmx.ErrorRenderer{}.Encode(w, partialRequest(), err)
+
+	for header, expected := range map[string]string{
+		"Content-Type":           "text/html; charset=utf-8",
+		"X-Content-Type-Options": "nosniff",
+	} {
+		if got := w.Header().Get(header); got != expected {
+			t.Errorf(
+				"%v: expected %q, got %q",
+				header,
+				expected,
+				got,
+			)
+		}
+	}
+	if got := w.Header().Get("Vary"); !strings.Contains(got, "Hx-Request") {
+		t.Errorf("Vary does not contain Hx-Request: %v", got)
+	}
+}
+
+func TestErrorRenderer_Encode_chooses_component_per_kind(t *testing.T) {
+	renderer := htmx.ErrorRenderer{
+		Components: map[apperror.Kind]htmx.ErrorComponent{
+			apperror.NotFoundError: textComponent("not found"),
+		},
+		DefaultComponent: textComponent("default"),
+	}
+
+	for _, tc := range []struct {
+		err      error
+		expected string
+	}{
+		{err: apperror.NotFound(errorMessage), expected: "not found"},
+		{err: apperror.Conflict(errorMessage), expected: "default"},
+	} {
+		t.Run(tc.expected, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			renderer.Encode(w, partialRequest(), tc.err)
+
+			if got := w.Body.String(); got != tc.expected {
+				t.Errorf(
+					"expected %q, got %q",
+					tc.expected,
+					got,
+				)
+			}
+		})
+	}
+}
+
+func TestErrorRenderer_Encode_passes_view_to_components(t *testing.T) {
+	violation := apperror.Violation{
+		Field:   "quantity",
+		Message: "quantity must be positive",
+	}
+	err := apperror.WithViolations(
+		apperror.Validation(errorMessage),
+		violation,
+	)
+
+	var got htmx.ErrorView
+	renderer := htmx.ErrorRenderer{
+		DefaultComponent: func(view htmx.ErrorView) htmx.Template {
+			got = view
+			return textComponent("")(view)
+		},
+	}
+	renderer.Encode(httptest.NewRecorder(), partialRequest(), err)
+
+	if expected := apperror.ValidationError; got.Kind != expected {
+		t.Errorf("expected %v, got %v", expected, got.Kind)
+	}
+	if expected := http.StatusBadRequest; got.Status != expected {
+		t.Errorf("expected %v, got %v", expected, got.Status)
+	}
+	if got.Message != errorMessage {
+		t.Errorf(`expected "%v", got "%v"`, errorMessage, got.Message)
+	}
+	if len(got.Violations) != 1 || got.Violations[0] != violation {
+		t.Errorf("expected %v, got %v", violation, got.Violations)
+	}
+}
+
+func TestErrorRenderer_Encode_redacts_unknown_errors(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := errors.New("an unknown error")
+	htmx.ErrorRenderer{}.Encode(w, partialRequest(), err)
+
+	body := w.Body.String()
+	if strings.Contains(body, "an unknown error") {
+		t.Error("leaked message:", body)
+	}
+	if !strings.Contains(body, "Internal Server Error") {
+		t.Error("missing status text:", body)
+	}
+}
+
+func TestErrorRenderer_Encode_escapes_messages(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := apperror.Conflict("<script>")
+	htmx.ErrorRenderer{}.Encode(w, navigationRequest(), err)
+
+	if body := w.Body.String(); strings.Contains(body, "<script>") {
+		t.Error("unescaped message:", body)
+	}
+}
+
+func TestErrorRenderer_Encode_retargets_partial_updates(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		renderer htmx.ErrorRenderer
+		target   string
+		swap     string
+	}{
+		{
+			name:     "No target",
+			renderer: htmx.ErrorRenderer{},
+		},
+		{
+			name:     "Default swap",
+			renderer: htmx.ErrorRenderer{Target: "#errors"},
+			target:   "#errors",
+			swap:     "innerHTML",
+		},
+		{
+			name: "Custom swap",
+			renderer: htmx.ErrorRenderer{
+				Target: "#errors",
+				Swap:   "beforeend",
+			},
+			target: "#errors",
+			swap:   "beforeend",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := apperror.Conflict(errorMessage)
+			tc.renderer.Encode(w, partialRequest(), err)
+
+			header := w.Header()
+			if got := header.Get("Hx-Retarget"); got != tc.target {
+				t.Errorf("expected %q, got %q", tc.target, got)
+			}
+			if got := header.Get("Hx-Reswap"); got != tc.swap {
+				t.Errorf("expected %q, got %q", tc.swap, got)
+			}
+		})
+	}
+}
+
+func TestErrorRenderer_Encode_renders_full_page_on_navigation(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := apperror.NotFound(errorMessage)
+	renderer := htmx.ErrorRenderer{Target: "#errors"}
+	renderer.Encode(w, navigationRequest(), err)
+
+	if got := w.Header().Get("Hx-Retarget"); got != "" {
+		t.Errorf(`unexpected Hx-Retarget "%v"`, got)
+	}
+
+	body := w.Body.String()
+	for _, expected := range []string{
+		"<!DOCTYPE html>",
+		"<title>Not Found</title>",
+		`<div class="error" role="alert" data-kind="not_found">`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("missing %v in %v", expected, body)
+		}
+	}
+}
+
+func TestErrorRenderer_Encode_titles_non_standard_status_codes(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := apperror.Canceled(errorMessage)
+	htmx.ErrorRenderer{}.Encode(w, navigationRequest(), err)
+
+	const expected = "<title>Client Closed Request</title>"
+	if body := w.Body.String(); !strings.Contains(body, expected) {
+		t.Errorf("missing %v in %v", expected, body)
+	}
+}
+
+func TestErrorRenderer_Encode_supports_custom_pages(t *testing.T) {
+	renderer := htmx.ErrorRenderer{
+		DefaultComponent: textComponent("component"),
+		Page: func(
+			view htmx.ErrorView,
+			component htmx.Template,
+		) htmx.Template {
+			var template Template
+			template.RenderFn = func(
+				ctx context.Context,
+				w io.Writer,
+			) error {
+				_, _ = fmt.Fprintf(w, "%v: ", view.Status)
+				return component.Render(ctx, w)
+			}
+			return template
+		},
+	}
+
+	w := httptest.NewRecorder()
+	err := apperror.Forbidden(errorMessage)
+	renderer.Encode(w, navigationRequest(), err)
+
+	const expected = "403: component"
+	if got := w.Body.String(); got != expected {
+		t.Errorf(`expected "%v", got "%v"`, expected, got)
+	}
+}
+
+func TestErrorRenderer_Encode_supports_error_notifications(t *testing.T) {
+	renderErr := errors.New("render error")
+	var notifiedError error
+	renderer := htmx.ErrorRenderer{
+		DefaultComponent: func(htmx.ErrorView) htmx.Template {
+			var template Template
+			template.RenderFn = func(
+				context.Context,
+				io.Writer,
+			) error {
+				return renderErr
+			}
+			return template
+		},
+		OnError: func(err error) {
+			notifiedError = err
+		},
+	}
+
+	err := apperror.Conflict(errorMessage)
+	renderer.Encode(httptest.NewRecorder(), navigationRequest(), err)
+
+	if !errors.Is(notifiedError, renderErr) {
+		t.Errorf("expected %v, got %v", renderErr, notifiedError)
+	}
+}
+
+func TestErrorRenderer_Encode_does_not_write_body_for_OK(t *testing.T) {
+	w := httptest.NewRecorder()
+	htmx.ErrorRenderer{}.Encode(w, partialRequest(), nil)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected %v, got %v", http.StatusOK, w.Code)
+	}
+	if body := w.Body.String(); body != "" {
+		t.Errorf("unexpected body: %v", body)
+	}
+}
+
+func TestErrorRenderer_Encode_is_an_httperror_Encoder(t *testing.T) {
+	var encoder httperror.Encoder = htmx.ErrorRenderer{}.Encode
+
+	w := httptest.NewRecorder()
+	encoder(w, partialRequest(), apperror.Gone(errorMessage))
+
+	if w.Code != http.StatusGone {
+		t.Errorf("expected %v, got %v", http.StatusGone, w.Code)
+	}
+}
+
+func partialRequest() *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Hx-Request", "true")
+	return r
+}
+
+func navigationRequest() *http.Request {
+	return httptest.NewRequest(http.MethodGet, "/", nil)
+}
+
+func textComponent(text string) htmx.ErrorComponent {
+	return func(htmx.ErrorView) htmx.Template {
+		var template Template
+		template.RenderFn = func(_ context.Context, w io.Writer) error {
+			_, err := io.WriteString(w, text)
+			return err
+		}
+		return template
+	}
+}
+
+const errorMessage = "test error"
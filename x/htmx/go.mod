@@ -1,3 +1,7 @@
 module artk.dev/x/htmx
 
 go 1.22.0
+
+require artk.dev v0.5.0
+
+replace artk.dev => ../../
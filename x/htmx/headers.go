@@ -4,5 +4,7 @@ const (
 	acceptHeader                  = "Accept"
 	hxHistoryRestoreRequestHeader = "Hx-History-Restore-Request"
 	hxRequestHeader               = "Hx-Request"
+	hxReswapHeader                = "Hx-Reswap"
+	hxRetargetHeader              = "Hx-Retarget"
 	hxTriggerHeader               = "Hx-Trigger"
 )
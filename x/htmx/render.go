@@ -25,15 +25,27 @@ func (r Renderer) Render(
 	w http.ResponseWriter,
 	template Template,
 ) {
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.Header().Set("Vary", varyHeaders)
+	setHeaders(w.Header())
+	r.render(ctx, w, template)
+}
 
+func (r Renderer) render(
+	ctx context.Context,
+	w http.ResponseWriter,
+	template Template,
+) {
 	if err := template.Render(ctx, w); err != nil && r.OnError != nil {
 		r.OnError(err)
 	}
 }
 
+// setHeaders sets the essential HTMX headers.
+func setHeaders(header http.Header) {
+	header.Set("Content-Type", "text/html; charset=utf-8")
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Vary", varyHeaders)
+}
+
 var varyHeaders = strings.Join([]string{
 	acceptHeader,
 	hxHistoryRestoreRequestHeader,